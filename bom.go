@@ -0,0 +1,132 @@
+package pom
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ManagedDependency is an entry of a project's dependencyManagement, along with where it came from
+type ManagedDependency struct {
+	*Dependency
+	// ImportedFrom is the chain of BOMs the entry was imported through as
+	// groupId:artifactId:version, outermost first.  It is empty for entries
+	// the project (or one of its parents) declares itself.
+	ImportedFrom []string
+}
+
+// Source returns the BOM the entry was imported from directly, or "" if it was not imported
+func (m ManagedDependency) Source() string {
+	if len(m.ImportedFrom) == 0 {
+		return ""
+	}
+	return m.ImportedFrom[len(m.ImportedFrom)-1]
+}
+
+// isImport checks for a <type>pom</type><scope>import</scope> entry of dependencyManagement
+func isImport(d *Dependency) bool {
+	dependencyType, _ := d.GetType()
+	scope, _ := d.GetScope()
+	return strings.TrimSpace(dependencyType) == "pom" && strings.TrimSpace(scope) == "import"
+}
+
+// ManagedDependencies returns the dependencyManagement of model with every
+// imported BOM expanded, the same way Maven does:
+//   - entries the project declares itself always win
+//   - after that, the first BOM to manage an artifact wins, in the order the imports are declared
+//   - BOMs that import other BOMs are expanded first, depth first
+// model is a project as it was read.  Its parents and any BOMs are found through resolver.
+func ManagedDependencies(model Model, resolver Resolver) ([]ManagedDependency, error) {
+	model, err := inherited(model, "", resolver)
+	if err != nil {
+		return nil, err
+	}
+	return expandImports(model, resolver, nil)
+}
+
+// expandImports expands the imports of model.  stack is the BOMs we are in the middle of importing.
+func expandImports(model Model, resolver Resolver, stack []string) ([]ManagedDependency, error) {
+	if model.DependencyManagement == nil || model.DependencyManagement.Dependencies == nil {
+		return nil, nil
+	}
+	result := make([]ManagedDependency, 0)
+	declared := make(map[string]bool)
+	imports := make([]*Dependency, 0)
+	for _, d := range model.DependencyManagement.Dependencies.Dependency {
+		if isImport(d) {
+			imports = append(imports, d)
+			continue
+		}
+		if key := dependencyKey(d); !declared[key] {
+			declared[key] = true
+			result = append(result, ManagedDependency{Dependency: d})
+		}
+	}
+
+	// Imports are usually written with properties for their versions
+	interpolator := &Interpolator{Model: model, SystemProperties: map[string]string{}}
+	for _, d := range imports {
+		groupID, _ := d.GetGroupID()
+		artifactID, _ := d.GetArtifactID()
+		version, _ := d.GetVersion()
+		groupID, _ = interpolator.Resolve(groupID)
+		artifactID, _ = interpolator.Resolve(artifactID)
+		version, _ = interpolator.Resolve(version)
+		id := groupID + ":" + artifactID + ":" + version
+		for _, importing := range stack {
+			if importing == id {
+				return nil, fmt.Errorf("pom: BOM %s imports itself through %s", id, strings.Join(append(stack, id), " -> "))
+			}
+		}
+
+		if resolver == nil {
+			return nil, &NotFoundError{GroupID: groupID, ArtifactID: artifactID, Version: version}
+		}
+		bom, err := resolver.Resolve(groupID, artifactID, version)
+		if err != nil {
+			return nil, err
+		}
+		bom, err = inherited(bom, "", resolver)
+		if err != nil {
+			return nil, err
+		}
+		bom = interpolateQuietly(bom, "")
+		entries, err := expandImports(bom, resolver, append(append([]string{}, stack...), id))
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			key := dependencyKey(entry.Dependency)
+			if declared[key] {
+				continue
+			}
+			declared[key] = true
+			entry.ImportedFrom = append([]string{id}, entry.ImportedFrom...)
+			result = append(result, entry)
+		}
+	}
+	return result, nil
+}
+
+// importBOMs replaces the dependencyManagement of model with its imports expanded
+func importBOMs(model Model, resolver Resolver) (Model, error) {
+	if model.DependencyManagement == nil || model.DependencyManagement.Dependencies == nil {
+		return model, nil
+	}
+	hasImports := false
+	for _, d := range model.DependencyManagement.Dependencies.Dependency {
+		hasImports = hasImports || isImport(d)
+	}
+	if !hasImports {
+		return model, nil
+	}
+	managed, err := expandImports(model, resolver, nil)
+	if err != nil {
+		return Model{}, err
+	}
+	dependencies := make([]*Dependency, 0, len(managed))
+	for _, entry := range managed {
+		dependencies = append(dependencies, entry.Dependency)
+	}
+	model.DependencyManagement.Dependencies.Dependency = dependencies
+	return model, nil
+}
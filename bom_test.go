@@ -0,0 +1,121 @@
+package pom
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// bomPom is a BOM called artifactID, with the given dependencyManagement entries
+func bomPom(artifactID, parent, properties, entries string) string {
+	return fmt.Sprintf(`<project>
+    %s
+    <groupId>com.example</groupId>
+    <artifactId>%s</artifactId>
+    <version>1</version>
+    <packaging>pom</packaging>
+    <properties>%s</properties>
+    <dependencyManagement>
+        <dependencies>%s
+        </dependencies>
+    </dependencyManagement>
+</project>`, parent, artifactID, properties, entries)
+}
+
+func managedEntry(groupID, artifactID, version string) string {
+	return fmt.Sprintf("\n<dependency><groupId>%s</groupId><artifactId>%s</artifactId><version>%s</version></dependency>", groupID, artifactID, version)
+}
+
+func importEntry(artifactID, version string) string {
+	return fmt.Sprintf("\n<dependency><groupId>com.example</groupId><artifactId>%s</artifactId><version>%s</version><type>pom</type><scope>import</scope></dependency>", artifactID, version)
+}
+
+var bomResolver = mapResolver{
+	"com.example:bom-parent:1": bomPom("bom-parent", "", "<jackson.version>2.10.0</jackson.version>", ""),
+	"com.example:platform-bom:1": bomPom("platform-bom",
+		"<parent><groupId>com.example</groupId><artifactId>bom-parent</artifactId><version>1</version></parent>", "",
+		managedEntry("com.fasterxml.jackson.core", "jackson-databind", "${jackson.version}")+
+			managedEntry("com.google.guava", "guava", "28.0-jre")+
+			importEntry("nested-bom", "1")),
+	"com.example:nested-bom:1": bomPom("nested-bom", "", "",
+		managedEntry("com.google.guava", "guava", "20.0")+
+			managedEntry("io.netty", "netty-all", "4.1.42.Final")),
+	"com.example:other-bom:1": bomPom("other-bom", "", "",
+		managedEntry("com.fasterxml.jackson.core", "jackson-databind", "2.9.0")+
+			managedEntry("org.slf4j", "slf4j-api", "1.7.28")),
+	"com.example:service-parent:1": bomPom("service-parent", "", "<platform.version>1</platform.version>",
+		importEntry("platform-bom", "${platform.version}")),
+	"com.example:loop-bom:1": bomPom("loop-bom", "", "", importEntry("loop-bom", "1")),
+}
+
+var bomServicePom = `<project>
+    <parent>
+        <groupId>com.example</groupId>
+        <artifactId>service-parent</artifactId>
+        <version>1</version>
+    </parent>
+    <artifactId>service</artifactId>
+    <dependencyManagement>
+        <dependencies>
+            <dependency>
+                <groupId>org.apache.commons</groupId>
+                <artifactId>commons-lang3</artifactId>
+                <version>3.9</version>
+            </dependency>
+            <dependency>
+                <groupId>com.example</groupId>
+                <artifactId>other-bom</artifactId>
+                <version>1</version>
+                <type>pom</type>
+                <scope>import</scope>
+            </dependency>
+        </dependencies>
+    </dependencyManagement>
+</project>`
+
+func TestManagedDependencies(t *testing.T) {
+	a := assert.New(t)
+	model, err := Unmarshal([]byte(bomServicePom))
+	a.NoError(err)
+
+	managed, err := ManagedDependencies(model, bomResolver)
+	a.NoError(err)
+	entries := make([]string, 0)
+	for _, entry := range managed {
+		artifactID, _ := entry.GetArtifactID()
+		version, _ := entry.GetVersion()
+		entries = append(entries, fmt.Sprintf("%s:%s %v", artifactID, version, entry.ImportedFrom))
+	}
+	// The child's import comes before the parent's, since the child's dependencyManagement is merged in first
+	a.Equal([]string{
+		"commons-lang3:3.9 []",
+		"jackson-databind:2.9.0 [com.example:other-bom:1]",
+		"slf4j-api:1.7.28 [com.example:other-bom:1]",
+		"guava:28.0-jre [com.example:platform-bom:1]",
+		"netty-all:4.1.42.Final [com.example:platform-bom:1 com.example:nested-bom:1]",
+	}, entries)
+	a.Equal("com.example:nested-bom:1", managed[4].Source())
+	a.Equal("", managed[0].Source())
+
+	// The effective model has the imports expanded in place of the import entries
+	effective, err := Effective(model, bomResolver)
+	a.NoError(err)
+	a.Len(effective.DependencyManagement.Dependencies.Dependency, 5)
+	for _, d := range effective.DependencyManagement.Dependencies.Dependency {
+		a.False(isImport(d))
+	}
+}
+
+func TestManagedDependenciesErrors(t *testing.T) {
+	a := assert.New(t)
+	model, err := Unmarshal([]byte(bomPom("app", "", "", importEntry("loop-bom", "1"))))
+	a.NoError(err)
+	_, err = Effective(model, bomResolver)
+	a.EqualError(err, "pom: BOM com.example:loop-bom:1 imports itself through com.example:loop-bom:1 -> com.example:loop-bom:1")
+
+	model, err = Unmarshal([]byte(bomPom("app", "", "", importEntry("missing-bom", "1"))))
+	a.NoError(err)
+	_, err = Effective(model, bomResolver)
+	a.Equal(&NotFoundError{GroupID: "com.example", ArtifactID: "missing-bom", Version: "1"}, err)
+}
@@ -0,0 +1,263 @@
+package pom
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// singleByteCharset holds the runes of the bytes 0x80 to 0xFF of a charset.
+// The bytes below 0x80 are the same as ASCII.
+type singleByteCharset struct {
+	high    [128]rune
+	encoder map[rune]byte
+}
+
+func newSingleByteCharset(high func(b byte) rune) *singleByteCharset {
+	cs := &singleByteCharset{encoder: make(map[rune]byte)}
+	for i := range cs.high {
+		cs.high[i] = high(byte(0x80 + i))
+		if cs.high[i] != utf8.RuneError {
+			cs.encoder[cs.high[i]] = byte(0x80 + i)
+		}
+	}
+	return cs
+}
+
+// windows1252 is ISO-8859-1 with printable characters in place of most of the C1 controls.
+// The five bytes it leaves undefined are kept as the controls, so they still read and write back.
+var windows1252 = map[byte]rune{
+	0x80: '€', 0x82: '‚', 0x83: 'ƒ', 0x84: '„', 0x85: '…', 0x86: '†', 0x87: '‡', 0x88: 'ˆ',
+	0x89: '‰', 0x8A: 'Š', 0x8B: '‹', 0x8C: 'Œ', 0x8E: 'Ž', 0x91: '‘', 0x92: '’', 0x93: '“',
+	0x94: '”', 0x95: '•', 0x96: '–', 0x97: '—', 0x98: '˜', 0x99: '™', 0x9A: 'š', 0x9B: '›',
+	0x9C: 'œ', 0x9E: 'ž', 0x9F: 'Ÿ',
+}
+
+var (
+	latin1Charset = newSingleByteCharset(func(b byte) rune { return rune(b) })
+	cp1252Charset = newSingleByteCharset(func(b byte) rune {
+		if r, ok := windows1252[b]; ok {
+			return r
+		}
+		return rune(b)
+	})
+	asciiCharset = newSingleByteCharset(func(b byte) rune { return utf8.RuneError })
+)
+
+// charsets are the charsets other than UTF-8 POMs can be read and written in, by their lower case names
+var charsets = map[string]*singleByteCharset{
+	"iso-8859-1":   latin1Charset,
+	"iso8859-1":    latin1Charset,
+	"iso_8859-1":   latin1Charset,
+	"latin1":       latin1Charset,
+	"latin-1":      latin1Charset,
+	"l1":           latin1Charset,
+	"cp819":        latin1Charset,
+	"windows-1252": cp1252Charset,
+	"cp1252":       cp1252Charset,
+	"x-cp1252":     cp1252Charset,
+	"us-ascii":     asciiCharset,
+	"ascii":        asciiCharset,
+}
+
+// isUTF8 is true for the names UTF-8 goes by, and for no charset at all
+func isUTF8(charset string) bool {
+	switch strings.ToLower(strings.TrimSpace(charset)) {
+	case "", "utf-8", "utf8":
+		return true
+	}
+	return false
+}
+
+// CharsetReader reads POMs written in ISO-8859-1, windows-1252 or US-ASCII as UTF-8.
+// It is what Decoder uses by default, and can be given to an encoding/xml Decoder too.
+// Any other charset has to be read with a CharsetReader of your own.
+func CharsetReader(charset string, input io.Reader) (io.Reader, error) {
+	if isUTF8(charset) {
+		return input, nil
+	}
+	cs, ok := charsets[strings.ToLower(strings.TrimSpace(charset))]
+	if !ok {
+		return nil, fmt.Errorf("pom: unsupported charset %s", charset)
+	}
+	return &charsetReader{r: input, cs: cs}, nil
+}
+
+// CharsetWriter writes UTF-8 out as ISO-8859-1, windows-1252 or US-ASCII, and is what Encoder
+// uses by default.  Characters the charset does not have are written as character references, like &#8364;
+// Those mean nothing inside of comments and CDATA sections, so such characters there are an error instead.
+// The writer has to be closed once everything is written.
+func CharsetWriter(charset string, output io.Writer) (io.WriteCloser, error) {
+	if isUTF8(charset) {
+		return nopCloser{output}, nil
+	}
+	cs, ok := charsets[strings.ToLower(strings.TrimSpace(charset))]
+	if !ok {
+		return nil, fmt.Errorf("pom: unsupported charset %s", charset)
+	}
+	return &charsetWriter{w: output, cs: cs, name: charset}, nil
+}
+
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error {
+	return nil
+}
+
+type charsetReader struct {
+	r       io.Reader
+	cs      *singleByteCharset
+	pending []byte
+}
+
+func (r *charsetReader) Read(p []byte) (int, error) {
+	for len(r.pending) == 0 {
+		// Every byte can take up to three once it is UTF-8
+		buf := make([]byte, len(p)/3+1)
+		n, err := r.r.Read(buf)
+		for _, b := range buf[:n] {
+			if b < utf8.RuneSelf {
+				r.pending = append(r.pending, b)
+			} else {
+				var encoded [utf8.UTFMax]byte
+				r.pending = append(r.pending, encoded[:utf8.EncodeRune(encoded[:], r.cs.high[b-0x80])]...)
+			}
+		}
+		if err != nil && len(r.pending) == 0 {
+			return 0, err
+		}
+	}
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+	return n, nil
+}
+
+// markup is where in a document a charsetWriter is
+type markup int
+
+const (
+	inText markup = iota
+	inComment
+	inCDATA
+)
+
+// markupChanges are what moves a charsetWriter into and out of comments and CDATA sections
+var markupChanges = []struct {
+	from   markup
+	marker string
+	to     markup
+}{
+	{inText, "<!--", inComment},
+	{inText, "<![CDATA[", inCDATA},
+	{inComment, "-->", inText},
+	{inCDATA, "]]>", inText},
+}
+
+type charsetWriter struct {
+	w    io.Writer
+	cs   *singleByteCharset
+	name string
+	// partial is the start of a rune split across two writes
+	partial []byte
+	// markup and recent, the last few ASCII bytes written, tell comments and CDATA sections apart from text
+	markup markup
+	recent []byte
+}
+
+func (w *charsetWriter) Write(p []byte) (int, error) {
+	data := append(w.partial, p...)
+	out := make([]byte, 0, len(data))
+	for len(data) > 0 {
+		if !utf8.FullRune(data) {
+			break
+		}
+		r, size := utf8.DecodeRune(data)
+		data = data[size:]
+		if r < utf8.RuneSelf {
+			out = append(out, byte(r))
+			w.track(byte(r))
+		} else if b, ok := w.cs.encoder[r]; ok {
+			out = append(out, b)
+		} else if w.markup == inText {
+			out = append(out, fmt.Sprintf("&#%d;", r)...)
+		} else {
+			return 0, fmt.Errorf("pom: %s has no %q, and it can not be escaped inside of a comment or CDATA section", w.name, r)
+		}
+	}
+	w.partial = append([]byte{}, data...)
+	if _, err := w.w.Write(out); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// track follows the markup with each ASCII byte written
+func (w *charsetWriter) track(b byte) {
+	w.recent = append(w.recent, b)
+	if len(w.recent) > len("<![CDATA[") {
+		w.recent = w.recent[1:]
+	}
+	for _, change := range markupChanges {
+		if w.markup == change.from && bytes.HasSuffix(w.recent, []byte(change.marker)) {
+			w.markup = change.to
+			w.recent = w.recent[:0]
+			return
+		}
+	}
+}
+
+// Close fails if the last write stopped part of the way through a character
+func (w *charsetWriter) Close() error {
+	if len(w.partial) == 0 {
+		return nil
+	}
+	w.partial = nil
+	return errors.New("pom: incomplete UTF-8 sequence at end of output")
+}
+
+// passthroughCharsetReader is for POMs that have already been turned into UTF-8, but still declare their own charset
+func passthroughCharsetReader(charset string, input io.Reader) (io.Reader, error) {
+	return input, nil
+}
+
+var declaredCharset = regexp.MustCompile(`^\s*<\?xml[^>]*\sencoding\s*=\s*["']([^"']+)["']`)
+
+// toUTF8 returns rawPom as UTF-8, along with the charset it declares if that is not UTF-8.
+// The declaration itself is left as it is.
+func toUTF8(rawPom []byte) ([]byte, string, error) {
+	match := declaredCharset.FindSubmatch(bytes.TrimPrefix(rawPom, []byte("\xef\xbb\xbf")))
+	if match == nil || isUTF8(string(match[1])) {
+		return rawPom, "", nil
+	}
+	charset := string(match[1])
+	r, err := CharsetReader(charset, bytes.NewReader(rawPom))
+	if err != nil {
+		return nil, charset, err
+	}
+	var b bytes.Buffer
+	_, err = b.ReadFrom(r)
+	return b.Bytes(), charset, err
+}
+
+// fromUTF8 writes data back out in charset
+func fromUTF8(data []byte, charset string) ([]byte, error) {
+	if isUTF8(charset) {
+		return data, nil
+	}
+	var b bytes.Buffer
+	w, err := CharsetWriter(charset, &b)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = w.Write(data); err != nil {
+		return nil, err
+	}
+	err = w.Close()
+	return b.Bytes(), err
+}
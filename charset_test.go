@@ -0,0 +1,126 @@
+package pom
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// latin1Pom is written in ISO-8859-1, so é is the single byte 0xE9
+var latin1Pom = "<?xml version=\"1.0\" encoding=\"ISO-8859-1\"?>\n" +
+	"<project>\n" +
+	"    <artifactId>accents</artifactId>\n" +
+	"    <developers>\n" +
+	"        <developer>\n" +
+	"            <name>Ren\xe9 Fran\xe7ois</name>\n" +
+	"        </developer>\n" +
+	"    </developers>\n" +
+	"</project>\n"
+
+func TestUnmarshalCharset(t *testing.T) {
+	a := assert.New(t)
+	model, err := Unmarshal([]byte(latin1Pom))
+	a.NoError(err)
+	a.Equal("René François", *model.Developers.Developer[0].Name)
+	a.Equal("ISO-8859-1", model.Encoding)
+
+	utf8Model, err := Unmarshal([]byte(strings.Replace(examplePom, "<project", `<?xml version="1.0" encoding="UTF-8"?><project`, 1)))
+	a.NoError(err)
+	a.Equal("", utf8Model.Encoding)
+
+	model.Developers.Developer[0].Name = optional("Zoë € Łukasz")
+	data, err := Marshal(model)
+	a.NoError(err)
+	a.True(strings.HasPrefix(string(data), `<?xml version="1.0" encoding="ISO-8859-1"?>`))
+	a.Contains(string(data), "<name>Zo\xeb &#8364; &#321;ukasz</name>", "characters latin-1 does not have are escaped")
+	again, err := Unmarshal(data)
+	a.NoError(err)
+	a.Equal("Zoë € Łukasz", *again.Developers.Developer[0].Name)
+
+	model.Encoding = "windows-1252"
+	data, err = Marshal(model)
+	a.NoError(err)
+	a.Contains(string(data), "<name>Zo\xeb \x80 &#321;ukasz</name>")
+
+	_, err = Unmarshal([]byte(`<?xml version="1.0" encoding="EBCDIC"?><project/>`))
+	a.EqualError(err, "pom: unsupported charset EBCDIC")
+}
+
+func TestDocumentCharset(t *testing.T) {
+	a := assert.New(t)
+	doc, err := UnmarshalDocument([]byte(latin1Pom))
+	a.NoError(err)
+	data, err := MarshalDocument(doc)
+	a.NoError(err)
+	a.Equal(latin1Pom, string(data), "the document is written back byte for byte")
+
+	doc.Model.SetArtifactID("accentué")
+	data, err = MarshalDocument(doc)
+	a.NoError(err)
+	a.Equal(strings.Replace(latin1Pom, "accents", "accentu\xe9", 1), string(data))
+
+	a.NoError(Check("pom.xml", []byte(latin1Pom)))
+	positions, err := ReadPositions([]byte(latin1Pom), nil)
+	a.NoError(err)
+	position, ok := positions.Lookup("project/developers/developer/name")
+	a.True(ok)
+	a.Equal(6, position.Line)
+
+	repaired, problems, err := Repair([]byte(strings.Replace(latin1Pom, "Ren\xe9", "R&D Ren\xe9", 1)))
+	a.NoError(err)
+	a.Len(problems, 2)
+	a.Contains(string(repaired), "<name>R&amp;D Ren\xe9 Fran\xe7ois</name>")
+}
+
+func TestStreamCharset(t *testing.T) {
+	a := assert.New(t)
+	var model Model
+	a.NoError(NewDecoder(strings.NewReader(latin1Pom)).Decode(&model))
+	a.Equal("René François", *model.Developers.Developer[0].Name)
+	a.Equal("ISO-8859-1", model.Encoding)
+
+	var b bytes.Buffer
+	enc := NewEncoder(&b)
+	enc.Charset = "US-ASCII"
+	a.NoError(enc.Encode(model))
+	a.Contains(b.String(), `encoding="US-ASCII"`)
+	a.Contains(b.String(), "<name>Ren&#233; Fran&#231;ois</name>")
+
+	// Runes split across writes are put back together
+	w, err := CharsetWriter("windows-1252", &b)
+	a.NoError(err)
+	b.Reset()
+	euro := []byte("€")
+	w.Write(euro[:1])
+	w.Write(euro[1:])
+	a.NoError(w.Close())
+	a.Equal("\x80", b.String())
+
+	// A rune that never finishes is an error, not a guess
+	w.Write(euro[:2])
+	a.EqualError(w.Close(), "pom: incomplete UTF-8 sequence at end of output")
+	a.Equal("\x80", b.String())
+
+	// Character references are only text, so they can not stand in inside of comments or CDATA
+	w, err = CharsetWriter("ISO-8859-1", &b)
+	a.NoError(err)
+	_, err = w.Write([]byte("<a>€<!-- \u00e9 -->"))
+	a.NoError(err)
+	_, err = w.Write([]byte("<![CDATA[€]]>"))
+	a.EqualError(err, `pom: ISO-8859-1 has no '€', and it can not be escaped inside of a comment or CDATA section`)
+
+	model.Properties = &XMLProperties{}
+	model.Properties.Set("currency", "€")
+	model.Properties.Elements[0].Comment = xml.Comment(" € ")
+	a.Error(enc.Encode(model))
+
+	r, err := CharsetReader("cp1252", strings.NewReader("\x93quoted\x94"))
+	a.NoError(err)
+	read, err := ioutil.ReadAll(r)
+	a.NoError(err)
+	a.Equal("“quoted”", string(read))
+}
@@ -0,0 +1,53 @@
+package pom
+
+import "reflect"
+
+// deepCopy returns a copy of v that shares no pointers, slices or maps with it.
+// Merging models copies everything it takes from a parent with this, so the
+// effective model can be edited without touching the models it was built from.
+func deepCopy(v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+	original := reflect.ValueOf(v)
+	result := reflect.New(original.Type()).Elem()
+	copyValue(result, original)
+	return result.Interface()
+}
+
+func copyValue(dst, src reflect.Value) {
+	switch src.Kind() {
+	case reflect.Ptr:
+		if src.IsNil() {
+			return
+		}
+		dst.Set(reflect.New(src.Type().Elem()))
+		copyValue(dst.Elem(), src.Elem())
+	case reflect.Slice:
+		if src.IsNil() {
+			return
+		}
+		dst.Set(reflect.MakeSlice(src.Type(), src.Len(), src.Len()))
+		for i := 0; i < src.Len(); i++ {
+			copyValue(dst.Index(i), src.Index(i))
+		}
+	case reflect.Map:
+		if src.IsNil() {
+			return
+		}
+		dst.Set(reflect.MakeMapWithSize(src.Type(), src.Len()))
+		for _, key := range src.MapKeys() {
+			value := reflect.New(src.Type().Elem()).Elem()
+			copyValue(value, src.MapIndex(key))
+			dst.SetMapIndex(key, value)
+		}
+	case reflect.Struct:
+		for i := 0; i < src.NumField(); i++ {
+			if dst.Field(i).CanSet() {
+				copyValue(dst.Field(i), src.Field(i))
+			}
+		}
+	default:
+		dst.Set(src)
+	}
+}
@@ -0,0 +1,56 @@
+// versions-set changes the version of a project across a multi-module build,
+// the same way mvn versions:set does, and lists every change it made.
+//
+//	versions-set -f pom.xml -newVersion 1.2.0
+//	versions-set -artifactId core -newVersion 2.0.0-SNAPSHOT -dry-run
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/SirAlvarex/pom"
+)
+
+func main() {
+	file := flag.String("f", "pom.xml", "the root POM of the reactor")
+	groupID := flag.String("groupId", "", "groupId of the project to change (defaults to the root project)")
+	artifactID := flag.String("artifactId", "", "artifactId of the project to change (defaults to the root project)")
+	newVersion := flag.String("newVersion", "", "the new version")
+	dryRun := flag.Bool("dry-run", false, "list the changes without writing them")
+	flag.Parse()
+
+	if *newVersion == "" {
+		fmt.Fprintln(os.Stderr, "versions-set: -newVersion is required")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	reactor, err := pom.LoadReactor(*file, nil)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	root := reactor.Projects[0]
+	if *groupID == "" {
+		*groupID = root.GroupID
+	}
+	if *artifactID == "" {
+		*artifactID = root.ArtifactID
+	}
+
+	update, err := reactor.SetVersion(*groupID, *artifactID, *newVersion)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	fmt.Print(update.Report())
+	if *dryRun {
+		return
+	}
+	if err := update.Write(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
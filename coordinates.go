@@ -0,0 +1,242 @@
+package pom
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Coordinates identify an artifact: groupId:artifactId[:type[:classifier]]:version
+type Coordinates struct {
+	GroupID    string
+	ArtifactID string
+	Version    string
+	// Type is the kind of artifact, such as jar or pom.  Empty means jar.
+	Type       string
+	Classifier string
+}
+
+// ParseCoordinates reads coordinates written as any of
+//   groupId:artifactId
+//   groupId:artifactId:version
+//   groupId:artifactId:type:version
+//   groupId:artifactId:type:classifier:version
+// The short groupId:artifactId form is only useful as a pattern for Match.
+func ParseCoordinates(s string) (Coordinates, error) {
+	parts := strings.Split(strings.TrimSpace(s), ":")
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+	var c Coordinates
+	switch len(parts) {
+	case 2:
+		c = Coordinates{GroupID: parts[0], ArtifactID: parts[1]}
+	case 3:
+		c = Coordinates{GroupID: parts[0], ArtifactID: parts[1], Version: parts[2]}
+	case 4:
+		c = Coordinates{GroupID: parts[0], ArtifactID: parts[1], Type: parts[2], Version: parts[3]}
+	case 5:
+		c = Coordinates{GroupID: parts[0], ArtifactID: parts[1], Type: parts[2], Classifier: parts[3], Version: parts[4]}
+	default:
+		return Coordinates{}, fmt.Errorf("pom: bad coordinates %q, expected groupId:artifactId[:type[:classifier]]:version", s)
+	}
+	if c.GroupID == "" || c.ArtifactID == "" {
+		return Coordinates{}, fmt.Errorf("pom: bad coordinates %q, groupId and artifactId are required", s)
+	}
+	return c, nil
+}
+
+// String writes the coordinates in the form ParseCoordinates reads.
+// The type is only written if there is one, or if there is a classifier.
+func (c Coordinates) String() string {
+	parts := []string{c.GroupID, c.ArtifactID}
+	if c.Type != "" || c.Classifier != "" {
+		parts = append(parts, c.extensionType())
+		if c.Classifier != "" {
+			parts = append(parts, c.Classifier)
+		}
+		version := c.Version
+		if version == "" {
+			version = "*"
+		}
+		return strings.Join(append(parts, version), ":")
+	}
+	if c.Version != "" {
+		parts = append(parts, c.Version)
+	}
+	return strings.Join(parts, ":")
+}
+
+// extensionType returns the type, which defaults to jar
+func (c Coordinates) extensionType() string {
+	if c.Type == "" {
+		return "jar"
+	}
+	return c.Type
+}
+
+// Match checks whether other is matched by c used as a pattern.
+// Each part of c may contain * wildcards, and parts that are empty match anything.
+// A version that is a range, like [1.7,2), matches any version inside of it.
+// Usage:
+//   pattern, _ := pom.ParseCoordinates("org.slf4j:*")
+//   pattern.Match(dependency.Coordinates())
+func (c Coordinates) Match(other Coordinates) bool {
+	if !matchWildcard(c.GroupID, other.GroupID) || !matchWildcard(c.ArtifactID, other.ArtifactID) ||
+		!matchWildcard(c.Classifier, other.Classifier) {
+		return false
+	}
+	if c.Type != "" && !matchWildcard(c.Type, other.extensionType()) {
+		return false
+	}
+	if strings.ContainsAny(c.Version, "[(") {
+		versionRange, err := ParseVersionRange(c.Version)
+		return err == nil && versionRange.Contains(ParseVersion(other.Version))
+	}
+	return matchWildcard(c.Version, other.Version)
+}
+
+// matchWildcard matches value against pattern, where * matches any run of characters.
+// An empty pattern matches anything.
+func matchWildcard(pattern, value string) bool {
+	if pattern == "" {
+		return true
+	}
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == value
+	}
+	if !strings.HasPrefix(value, parts[0]) {
+		return false
+	}
+	value = value[len(parts[0]):]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(value, part)
+		if i < 0 {
+			return false
+		}
+		value = value[i+len(part):]
+	}
+	return strings.HasSuffix(value, parts[len(parts)-1])
+}
+
+// artifactHandlers maps the types Maven knows about to the file extension
+// and classifier their artifacts are stored with.  Any other type is its own extension.
+var artifactHandlers = map[string]struct{ extension, classifier string }{
+	"test-jar":     {"jar", "tests"},
+	"maven-plugin": {"jar", ""},
+	"ejb":          {"jar", ""},
+	"ejb-client":   {"jar", "client"},
+	"java-source":  {"jar", "sources"},
+	"javadoc":      {"jar", "javadoc"},
+	"bundle":       {"jar", ""},
+}
+
+// Path returns where the artifact lives in a Maven repository, always with / separators.
+// Usage:
+//   c := pom.Coordinates{GroupID: "org.slf4j", ArtifactID: "slf4j-api", Version: "1.7.6"}
+//   c.Path() // org/slf4j/slf4j-api/1.7.6/slf4j-api-1.7.6.jar
+func (c Coordinates) Path() string {
+	extension, classifier := c.extensionType(), c.Classifier
+	if handler, ok := artifactHandlers[extension]; ok {
+		extension = handler.extension
+		if classifier == "" {
+			classifier = handler.classifier
+		}
+	}
+	file := c.ArtifactID + "-" + c.Version
+	if classifier != "" {
+		file += "-" + classifier
+	}
+	return strings.Join([]string{strings.Replace(c.GroupID, ".", "/", -1), c.ArtifactID, c.Version, file + "." + extension}, "/")
+}
+
+// optional returns nil for an empty string, so that conversions leave out anything that is not set
+func optional(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+// trimmed reads an optional field, trimming the whitespace around it
+func trimmed(field *string) string {
+	if field == nil {
+		return ""
+	}
+	return strings.TrimSpace(*field)
+}
+
+// Coordinates returns the coordinates of the dependency
+func (a *Dependency) Coordinates() Coordinates {
+	return Coordinates{GroupID: trimmed(a.GroupID), ArtifactID: trimmed(a.ArtifactID), Version: trimmed(a.Version), Type: trimmed(a.Type), Classifier: trimmed(a.Classifier)}
+}
+
+// Coordinates returns the coordinates of the plugin.  The groupId defaults to org.apache.maven.plugins.
+func (a *Plugin) Coordinates() Coordinates {
+	groupID := trimmed(a.GroupID)
+	if groupID == "" {
+		groupID = "org.apache.maven.plugins"
+	}
+	return Coordinates{GroupID: groupID, ArtifactID: trimmed(a.ArtifactID), Version: trimmed(a.Version)}
+}
+
+// Coordinates returns the coordinates of the parent POM
+func (a *Parent) Coordinates() Coordinates {
+	return Coordinates{GroupID: trimmed(a.GroupID), ArtifactID: trimmed(a.ArtifactID), Version: trimmed(a.Version), Type: "pom"}
+}
+
+// Coordinates returns the coordinates of the extension
+func (a *Extension) Coordinates() Coordinates {
+	return Coordinates{GroupID: trimmed(a.GroupID), ArtifactID: trimmed(a.ArtifactID), Version: trimmed(a.Version)}
+}
+
+// Coordinates returns the exclusion as a pattern for Coordinates.Match
+func (a *Exclusion) Coordinates() Coordinates {
+	return Coordinates{GroupID: trimmed(a.GroupID), ArtifactID: trimmed(a.ArtifactID)}
+}
+
+// Coordinates returns where the artifact was relocated to.  Parts that are
+// not set stay the same as the artifact being relocated, which is from.
+func (a *Relocation) Coordinates(from Coordinates) Coordinates {
+	to := from
+	if groupID := trimmed(a.GroupID); groupID != "" {
+		to.GroupID = groupID
+	}
+	if artifactID := trimmed(a.ArtifactID); artifactID != "" {
+		to.ArtifactID = artifactID
+	}
+	if version := trimmed(a.Version); version != "" {
+		to.Version = version
+	}
+	return to
+}
+
+// Dependency returns a dependency on the artifact
+func (c Coordinates) Dependency() *Dependency {
+	return &Dependency{GroupID: optional(c.GroupID), ArtifactID: optional(c.ArtifactID), Version: optional(c.Version), Type: optional(c.Type), Classifier: optional(c.Classifier)}
+}
+
+// Plugin returns a plugin declaration for the artifact
+func (c Coordinates) Plugin() *Plugin {
+	return &Plugin{GroupID: optional(c.GroupID), ArtifactID: optional(c.ArtifactID), Version: optional(c.Version)}
+}
+
+// Parent returns a parent declaration for the artifact
+func (c Coordinates) Parent() *Parent {
+	return &Parent{GroupID: optional(c.GroupID), ArtifactID: optional(c.ArtifactID), Version: optional(c.Version)}
+}
+
+// Extension returns a build extension declaration for the artifact
+func (c Coordinates) Extension() *Extension {
+	return &Extension{GroupID: optional(c.GroupID), ArtifactID: optional(c.ArtifactID), Version: optional(c.Version)}
+}
+
+// Exclusion returns an exclusion of the artifact.  Only the groupId and artifactId are used.
+func (c Coordinates) Exclusion() *Exclusion {
+	return &Exclusion{GroupID: optional(c.GroupID), ArtifactID: optional(c.ArtifactID)}
+}
+
+// Relocation returns a relocation to the artifact
+func (c Coordinates) Relocation() *Relocation {
+	return &Relocation{GroupID: optional(c.GroupID), ArtifactID: optional(c.ArtifactID), Version: optional(c.Version)}
+}
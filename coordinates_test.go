@@ -0,0 +1,78 @@
+package pom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCoordinates(t *testing.T) {
+	a := assert.New(t)
+	tests := map[string]Coordinates{
+		"org.slf4j:slf4j-api":                      {GroupID: "org.slf4j", ArtifactID: "slf4j-api"},
+		"org.slf4j:slf4j-api:1.7.6":                {GroupID: "org.slf4j", ArtifactID: "slf4j-api", Version: "1.7.6"},
+		"com.example:app:war:2.0":                  {GroupID: "com.example", ArtifactID: "app", Type: "war", Version: "2.0"},
+		"com.example:app:test-jar:tests:2.0":       {GroupID: "com.example", ArtifactID: "app", Type: "test-jar", Classifier: "tests", Version: "2.0"},
+		"io.netty:netty-transport:jar:linux:4.1.4": {GroupID: "io.netty", ArtifactID: "netty-transport", Type: "jar", Classifier: "linux", Version: "4.1.4"},
+	}
+	for s, expected := range tests {
+		c, err := ParseCoordinates(s)
+		a.NoError(err)
+		a.Equal(expected, c)
+		a.Equal(s, c.String())
+	}
+
+	for _, bad := range []string{"", "slf4j-api", ":slf4j-api:1", "a:b:c:d:e:f"} {
+		_, err := ParseCoordinates(bad)
+		a.Error(err, bad)
+	}
+	a.Equal("com.example:app:jar:linux:*", Coordinates{GroupID: "com.example", ArtifactID: "app", Classifier: "linux"}.String())
+}
+
+func TestCoordinatesMatch(t *testing.T) {
+	a := assert.New(t)
+	slf4j := Coordinates{GroupID: "org.slf4j", ArtifactID: "slf4j-api", Version: "1.7.6"}
+	tests := map[string]bool{
+		"org.slf4j:*":               true,
+		"org.slf4j:slf4j-*":         true,
+		"*:*-api":                   true,
+		"org.*:*:1.7.*":             true,
+		"org.slf4j:*:[1.7,2)":       true,
+		"org.slf4j:*:[1.8,)":        false,
+		"org.slf4j:*:jar:*":         true,
+		"org.slf4j:*:pom:*":         false,
+		"org.slf4j:*:jar:sources:*": false,
+		"ch.qos.logback:*":          false,
+	}
+	for pattern, expected := range tests {
+		c, err := ParseCoordinates(pattern)
+		a.NoError(err)
+		a.Equal(expected, c.Match(slf4j), pattern)
+	}
+}
+
+func TestCoordinatesPath(t *testing.T) {
+	a := assert.New(t)
+	a.Equal("org/slf4j/slf4j-api/1.7.6/slf4j-api-1.7.6.jar", Coordinates{GroupID: "org.slf4j", ArtifactID: "slf4j-api", Version: "1.7.6"}.Path())
+	a.Equal("com/example/app/2.0/app-2.0-tests.jar", Coordinates{GroupID: "com.example", ArtifactID: "app", Version: "2.0", Type: "test-jar"}.Path())
+	a.Equal("com/example/parent/1/parent-1.pom", Coordinates{GroupID: "com.example", ArtifactID: "parent", Version: "1", Type: "pom"}.Path())
+	a.Equal("com/example/app/2.0/app-2.0-linux.so", Coordinates{GroupID: "com.example", ArtifactID: "app", Version: "2.0", Type: "so", Classifier: "linux"}.Path())
+}
+
+func TestCoordinatesConversions(t *testing.T) {
+	a := assert.New(t)
+	c := Coordinates{GroupID: "com.example", ArtifactID: "app", Version: "2.0", Type: "war", Classifier: "client"}
+	a.Equal(c, c.Dependency().Coordinates())
+	a.Nil(Coordinates{GroupID: "g", ArtifactID: "a"}.Dependency().Version, "empty parts are left out")
+
+	a.Equal(Coordinates{GroupID: "org.apache.maven.plugins", ArtifactID: "maven-compiler-plugin", Version: "3.8.1"},
+		Coordinates{ArtifactID: "maven-compiler-plugin", Version: "3.8.1"}.Plugin().Coordinates())
+	a.Equal("com.example:app:pom:2.0", c.Parent().Coordinates().String())
+	a.Equal("com.example:app:2.0", c.Extension().Coordinates().String())
+	a.Equal("com.example:app", c.Exclusion().Coordinates().String())
+
+	relocation := &Relocation{GroupID: optional("org.example")}
+	a.Equal(Coordinates{GroupID: "org.example", ArtifactID: "app", Version: "2.0"},
+		relocation.Coordinates(Coordinates{GroupID: "com.example", ArtifactID: "app", Version: "2.0"}))
+	a.Equal(Coordinates{GroupID: "com.example", ArtifactID: "app", Version: "2.0"}, c.Relocation().Coordinates(Coordinates{}))
+}
@@ -0,0 +1,416 @@
+package pom
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// DependencyNode is one artifact in a resolved dependency graph.
+// The root node is the project itself.
+type DependencyNode struct {
+	GroupID    string
+	ArtifactID string
+	Version    string
+	Type       string
+	Classifier string
+	Scope      string
+	Optional   bool
+
+	// PremanagedVersion and PremanagedScope are what the dependency was declared
+	// with, if dependencyManagement replaced them
+	PremanagedVersion string
+	PremanagedScope   string
+	// ScopeUpdatedFrom is the scope the dependency had before it was widened
+	// because it was also needed with a wider scope somewhere else in the graph
+	ScopeUpdatedFrom string
+	// OmittedFor is the node that won mediation over this one, either because
+	// it was nearer to the root, or because it was declared first.
+	// Omitted nodes have no children.
+	OmittedFor *DependencyNode
+
+	Children []*DependencyNode
+
+	parent        *DependencyNode
+	depth         int
+	declaredScope string
+	exclusions    []*Exclusion
+	managed       map[string]*Dependency
+	omitted       []*DependencyNode
+}
+
+// Key identifies the artifact for mediation: groupId:artifactId:type:classifier
+func (n *DependencyNode) Key() string {
+	return n.GroupID + ":" + n.ArtifactID + ":" + n.Type + ":" + n.Classifier
+}
+
+// String returns the node the way Maven prints it: groupId:artifactId:type[:classifier]:version[:scope]
+func (n *DependencyNode) String() string {
+	parts := []string{n.GroupID, n.ArtifactID, n.Type}
+	if n.Classifier != "" {
+		parts = append(parts, n.Classifier)
+	}
+	parts = append(parts, n.Version)
+	if n.Scope != "" {
+		parts = append(parts, n.Scope)
+	}
+	return strings.Join(parts, ":")
+}
+
+// Parent returns the node that brought this one in, or nil for the root
+func (n *DependencyNode) Parent() *DependencyNode {
+	return n.parent
+}
+
+// Resolved returns every dependency that won mediation, in classpath order.
+// The root itself is not included.
+func (n *DependencyNode) Resolved() []*DependencyNode {
+	result := make([]*DependencyNode, 0)
+	var walk func(node *DependencyNode)
+	walk = func(node *DependencyNode) {
+		for _, child := range node.Children {
+			if child.OmittedFor == nil {
+				result = append(result, child)
+				walk(child)
+			}
+		}
+	}
+	walk(n)
+	return result
+}
+
+// DependencyResolver computes the transitive dependencies of a project from
+// the POMs that Resolver can find, the same way Maven does:
+//   - test and provided dependencies, and optional dependencies, are not transitive
+//   - the scope of a transitive dependency is derived from the scope that brought it in
+//   - exclusions apply to everything below the dependency that declares them
+//   - dependencyManagement of the project overrides transitive versions and scopes.  That of the
+//     dependencies themselves only fills in their own dependencies, like Maven 3's ClassicDependencyManager.
+//   - when an artifact is found more than once, the nearest wins, and after that the first declared
+type DependencyResolver struct {
+	Resolver Resolver
+
+	models map[string]Model
+}
+
+// NewDependencyResolver returns a DependencyResolver that reads POMs from
+// dir, a directory laid out like a Maven repository
+func NewDependencyResolver(dir string) *DependencyResolver {
+	return &DependencyResolver{Resolver: RepositoryResolver{Dir: dir}}
+}
+
+// Resolve computes the dependency graph of model.  Its parents are found through Resolver.
+func (r *DependencyResolver) Resolve(model Model) (*DependencyNode, error) {
+	effectiveModel, err := Effective(model, r.Resolver)
+	if err != nil {
+		return nil, err
+	}
+	return r.resolve(effectiveModel, "")
+}
+
+// ResolveFile reads the POM at path and computes its dependency graph.
+// Parents are looked for on disk first, the same way as EffectiveFile.
+func (r *DependencyResolver) ResolveFile(path string) (*DependencyNode, error) {
+	effectiveModel, err := EffectiveFile(path, r.Resolver)
+	if err != nil {
+		return nil, err
+	}
+	return r.resolve(effectiveModel, filepath.Dir(path))
+}
+
+func (r *DependencyResolver) resolve(model Model, baseDir string) (*DependencyNode, error) {
+	model = interpolateQuietly(model, baseDir)
+	groupID, _ := model.GetGroupID()
+	artifactID, _ := model.GetArtifactID()
+	version, _ := model.GetVersion()
+	packaging, ok := model.GetPackaging()
+	if !ok || packaging == "" {
+		packaging = "jar"
+	}
+	root := &DependencyNode{
+		GroupID:    groupID,
+		ArtifactID: artifactID,
+		Version:    version,
+		Type:       packaging,
+		managed:    managedBy(model),
+	}
+
+	// Walk the graph breadth first, so the nearest declaration of each artifact is seen first
+	winners := map[string]*DependencyNode{root.Key(): root}
+	resolved := make([]*DependencyNode, 0)
+	queue := []*dependencyExpansion{{node: root, model: model}}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, dependency := range managedDependencies(current.model) {
+			child := newDependencyNode(current.node, dependency)
+			if child == nil {
+				continue
+			}
+			if winner, ok := winners[child.Key()]; ok {
+				child.OmittedFor = winner
+				winner.omitted = append(winner.omitted, child)
+				current.node.Children = append(current.node.Children, child)
+				continue
+			}
+			if err := r.pickVersion(child); err != nil {
+				return nil, err
+			}
+			winners[child.Key()] = child
+			resolved = append(resolved, child)
+			current.node.Children = append(current.node.Children, child)
+			if child.Scope == "system" {
+				continue
+			}
+			childModel, err := r.model(child.GroupID, child.ArtifactID, child.Version)
+			if err != nil {
+				return nil, err
+			}
+			queue = append(queue, &dependencyExpansion{node: child, model: childModel})
+		}
+	}
+
+	updateScopes(resolved)
+	return root, nil
+}
+
+// dependencyExpansion is a node waiting to have its dependencies added
+type dependencyExpansion struct {
+	node  *DependencyNode
+	model Model
+}
+
+// newDependencyNode creates the node for a dependency declared by parent,
+// or returns nil if the dependency does not make it into the graph
+func newDependencyNode(parent *DependencyNode, dependency *Dependency) *DependencyNode {
+	groupID, _ := dependency.GetGroupID()
+	artifactID, _ := dependency.GetArtifactID()
+	version, _ := dependency.GetVersion()
+	dependencyType, ok := dependency.GetType()
+	if !ok || dependencyType == "" {
+		dependencyType = "jar"
+	}
+	classifier, _ := dependency.GetClassifier()
+	scope, ok := dependency.GetScope()
+	if !ok || scope == "" {
+		scope = "compile"
+	}
+	optional, _ := dependency.GetOptional()
+	node := &DependencyNode{
+		GroupID:       groupID,
+		ArtifactID:    artifactID,
+		Version:       version,
+		Type:          dependencyType,
+		Classifier:    classifier,
+		Optional:      optional == "true",
+		parent:        parent,
+		depth:         parent.depth + 1,
+		declaredScope: scope,
+		managed:       parent.managed,
+	}
+	if isExcluded(parent, groupID, artifactID) {
+		return nil
+	}
+
+	transitive := parent.parent != nil
+	if transitive {
+		if node.Optional || scope == "test" || scope == "provided" {
+			return nil
+		}
+		// dependencyManagement only overrides dependencies further down the graph
+		if managed, ok := parent.managed[dependencyKey(dependency)]; ok {
+			if managedVersion, ok := managed.GetVersion(); ok && managedVersion != version {
+				node.PremanagedVersion = version
+				node.Version = managedVersion
+			}
+			if managedScope, ok := managed.GetScope(); ok && managedScope != scope {
+				node.PremanagedScope = scope
+				node.declaredScope = managedScope
+			}
+			if managed.Exclusions != nil {
+				node.exclusions = append(node.exclusions, managed.Exclusions.Exclusion...)
+			}
+		}
+	}
+	if dependency.Exclusions != nil {
+		node.exclusions = append(node.exclusions, dependency.Exclusions.Exclusion...)
+	}
+	node.Scope = deriveScope(parent.Scope, node.declaredScope)
+	return node
+}
+
+// isExcluded checks the exclusions of node and everything above it
+func isExcluded(node *DependencyNode, groupID, artifactID string) bool {
+	for ; node != nil; node = node.parent {
+		for _, exclusion := range node.exclusions {
+			excludedGroupID, _ := exclusion.GetGroupID()
+			excludedArtifactID, _ := exclusion.GetArtifactID()
+			if (excludedGroupID == "*" || excludedGroupID == groupID) && (excludedArtifactID == "*" || excludedArtifactID == artifactID) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// deriveScope works out the scope of a transitive dependency from the scope
+// of the dependency that brought it in.  The root has no scope.
+func deriveScope(parentScope, scope string) string {
+	switch {
+	case scope == "system" || scope == "test":
+		return scope
+	case parentScope == "" || parentScope == "compile":
+		return scope
+	case parentScope == "test" || parentScope == "runtime":
+		return parentScope
+	case parentScope == "system" || parentScope == "provided":
+		return "provided"
+	}
+	return "runtime"
+}
+
+// scopeOrder is the order Maven prefers scopes in, when the same artifact is needed with several
+var scopeOrder = []string{"compile", "runtime", "provided", "test", "system"}
+
+// updateScopes widens the scope of each winning node to the widest scope it was
+// needed with.  Dependencies of the project itself always keep the scope they were declared with.
+// Widening a node can widen what is below it, so keep going until nothing changes.
+func updateScopes(resolved []*DependencyNode) {
+	for changed := true; changed; {
+		changed = false
+		for _, node := range resolved {
+			if node.depth == 1 {
+				continue
+			}
+			scopes := map[string]bool{deriveScope(node.parent.Scope, node.declaredScope): true}
+			for _, omitted := range node.omitted {
+				if omitted.depth == 1 {
+					// This is only possible for duplicates the project declares itself
+					continue
+				}
+				scopes[deriveScope(omitted.parent.Scope, omitted.declaredScope)] = true
+			}
+			for _, scope := range scopeOrder {
+				if !scopes[scope] {
+					continue
+				}
+				if scope != node.Scope {
+					if node.ScopeUpdatedFrom == "" {
+						node.ScopeUpdatedFrom = node.Scope
+					}
+					node.Scope = scope
+					changed = true
+				}
+				break
+			}
+		}
+	}
+	for _, node := range resolved {
+		if node.ScopeUpdatedFrom == node.Scope {
+			node.ScopeUpdatedFrom = ""
+		}
+	}
+}
+
+// pickVersion resolves a version range to the highest version the repository has
+func (r *DependencyResolver) pickVersion(node *DependencyNode) error {
+	versionRange, err := ParseVersionRange(node.Version)
+	if err != nil {
+		return err
+	}
+	if !versionRange.HasRestrictions() {
+		return nil
+	}
+	lister, ok := r.Resolver.(VersionLister)
+	if !ok {
+		return fmt.Errorf("pom: cannot resolve version range %s of %s:%s without a list of versions", node.Version, node.GroupID, node.ArtifactID)
+	}
+	available, err := lister.Versions(node.GroupID, node.ArtifactID)
+	if err != nil {
+		return err
+	}
+	versions := make([]Version, 0, len(available))
+	for _, version := range available {
+		versions = append(versions, ParseVersion(version))
+	}
+	match, ok := versionRange.Match(versions)
+	if !ok {
+		return &NotFoundError{GroupID: node.GroupID, ArtifactID: node.ArtifactID, Version: node.Version}
+	}
+	node.Version = match.String()
+	return nil
+}
+
+// model returns the interpolated effective model of a dependency
+func (r *DependencyResolver) model(groupID, artifactID, version string) (Model, error) {
+	key := groupID + ":" + artifactID + ":" + version
+	if model, ok := r.models[key]; ok {
+		return model, nil
+	}
+	model, err := r.Resolver.Resolve(groupID, artifactID, version)
+	if err != nil {
+		return model, err
+	}
+	model, err = Effective(model, r.Resolver)
+	if err != nil {
+		return model, err
+	}
+	model = interpolateQuietly(model, "")
+	if r.models == nil {
+		r.models = make(map[string]Model)
+	}
+	r.models[key] = model
+	return model, nil
+}
+
+// interpolateQuietly resolves the expressions in model.  Anything that cannot
+// be resolved is left as it is, since most of it (such as paths in the build)
+// does not matter for dependencies.
+func interpolateQuietly(model Model, baseDir string) Model {
+	interpolator := &Interpolator{Model: model, BaseDir: baseDir, SystemProperties: map[string]string{}}
+	result, _ := interpolator.InterpolateModel(model)
+	return result
+}
+
+// managedDependencies returns the dependencies of model with any version or
+// scope they leave out filled in from the model's own dependencyManagement
+func managedDependencies(model Model) []*Dependency {
+	if model.Dependencies == nil {
+		return nil
+	}
+	managed := managedBy(model)
+	result := make([]*Dependency, 0, len(model.Dependencies.Dependency))
+	for _, dependency := range model.Dependencies.Dependency {
+		if management, ok := managed[dependencyKey(dependency)]; ok {
+			filled := *dependency
+			if filled.Version == nil {
+				filled.Version = management.Version
+			}
+			if filled.Scope == nil {
+				filled.Scope = management.Scope
+			}
+			if filled.Exclusions == nil {
+				filled.Exclusions = management.Exclusions
+			}
+			dependency = &filled
+		}
+		result = append(result, dependency)
+	}
+	return result
+}
+
+// managedBy returns the dependencyManagement of model by dependency key.
+// The first declaration of a dependency wins.
+func managedBy(model Model) map[string]*Dependency {
+	result := make(map[string]*Dependency)
+	if model.DependencyManagement == nil || model.DependencyManagement.Dependencies == nil {
+		return result
+	}
+	for _, dependency := range model.DependencyManagement.Dependencies.Dependency {
+		key := dependencyKey(dependency)
+		if _, ok := result[key]; !ok {
+			result[key] = dependency
+		}
+	}
+	return result
+}
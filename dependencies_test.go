@@ -0,0 +1,225 @@
+package pom
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// dependencyRepository holds the POMs of a fixture repository, keyed by groupId:artifactId:version
+var dependencyRepository = map[string]string{
+	"com.example:a:1": `<project>
+    <groupId>com.example</groupId>
+    <artifactId>a</artifactId>
+    <version>1</version>
+    <properties>
+        <c.version>1</c.version>
+    </properties>
+    <dependencies>
+        <dependency><groupId>com.example</groupId><artifactId>c</artifactId><version>${c.version}</version></dependency>
+        <dependency><groupId>com.example</groupId><artifactId>d</artifactId><version>1</version><optional>true</optional></dependency>
+        <dependency><groupId>com.example</groupId><artifactId>p</artifactId><version>1</version><scope>provided</scope></dependency>
+        <dependency><groupId>com.example</groupId><artifactId>r</artifactId><version>1</version><scope>runtime</scope></dependency>
+    </dependencies>
+</project>`,
+	"com.example:b:1": `<project>
+    <parent>
+        <groupId>com.example</groupId>
+        <artifactId>b-parent</artifactId>
+        <version>1</version>
+    </parent>
+    <artifactId>b</artifactId>
+    <dependencies>
+        <dependency><groupId>com.example</groupId><artifactId>c</artifactId><version>3</version></dependency>
+        <dependency><groupId>com.example</groupId><artifactId>e</artifactId><version>1</version></dependency>
+        <dependency><groupId>com.example</groupId><artifactId>f</artifactId><version>[1,2)</version></dependency>
+        <dependency><groupId>com.example</groupId><artifactId>h</artifactId></dependency>
+    </dependencies>
+</project>`,
+	"com.example:b-parent:1": `<project>
+    <groupId>com.example</groupId>
+    <artifactId>b-parent</artifactId>
+    <version>1</version>
+    <packaging>pom</packaging>
+    <dependencyManagement>
+        <dependencies>
+            <dependency><groupId>com.example</groupId><artifactId>h</artifactId><version>1</version></dependency>
+        </dependencies>
+    </dependencyManagement>
+</project>`,
+	"com.example:t:1": `<project>
+    <groupId>com.example</groupId>
+    <artifactId>t</artifactId>
+    <version>1</version>
+    <dependencies>
+        <dependency><groupId>com.example</groupId><artifactId>h</artifactId><version>1</version></dependency>
+    </dependencies>
+</project>`,
+	"com.example:c:1":   leafPom("c", "1"),
+	"com.example:c:2":   leafPom("c", "2"),
+	"com.example:c:3":   leafPom("c", "3"),
+	"com.example:d:1":   leafPom("d", "1"),
+	"com.example:e:1":   leafPom("e", "1"),
+	"com.example:f:1.0": leafPom("f", "1.0"),
+	"com.example:f:1.5": leafPom("f", "1.5"),
+	"com.example:f:2.0": leafPom("f", "2.0"),
+	"com.example:h:1":   leafPom("h", "1"),
+	"com.example:p:1":   leafPom("p", "1"),
+	"com.example:r:1":   leafPom("r", "1"),
+}
+
+var dependencyProjectPom = `<project>
+    <groupId>com.example</groupId>
+    <artifactId>app</artifactId>
+    <version>1</version>
+    <dependencyManagement>
+        <dependencies>
+            <dependency><groupId>com.example</groupId><artifactId>c</artifactId><version>2</version></dependency>
+        </dependencies>
+    </dependencyManagement>
+    <dependencies>
+        <dependency><groupId>com.example</groupId><artifactId>a</artifactId><version>1</version></dependency>
+        <dependency><groupId>com.example</groupId><artifactId>t</artifactId><version>1</version><scope>test</scope></dependency>
+        <dependency>
+            <groupId>com.example</groupId>
+            <artifactId>b</artifactId>
+            <version>1</version>
+            <exclusions>
+                <exclusion><groupId>com.example</groupId><artifactId>e</artifactId></exclusion>
+            </exclusions>
+        </dependency>
+    </dependencies>
+</project>`
+
+func leafPom(artifactID, version string) string {
+	return fmt.Sprintf("<project><groupId>com.example</groupId><artifactId>%s</artifactId><version>%s</version></project>", artifactID, version)
+}
+
+// writeRepository lays poms out like a Maven repository under dir
+func writeRepository(dir string, poms map[string]string) error {
+	for key, pom := range poms {
+		parts := strings.Split(key, ":")
+		path := filepath.Join(dir, strings.Replace(parts[0], ".", string(filepath.Separator), -1), parts[1], parts[2])
+		if err := os.MkdirAll(path, 0755); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(filepath.Join(path, parts[1]+"-"+parts[2]+".pom"), []byte(pom), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestDependencyResolver(t *testing.T) {
+	a := assert.New(t)
+	dir, err := ioutil.TempDir("", "pom")
+	a.NoError(err)
+	defer os.RemoveAll(dir)
+	a.NoError(writeRepository(dir, dependencyRepository))
+
+	model, err := Unmarshal([]byte(dependencyProjectPom))
+	a.NoError(err)
+	root, err := NewDependencyResolver(dir).Resolve(model)
+	a.NoError(err)
+	a.Equal("com.example:app:jar:1", root.String())
+
+	resolved := make([]string, 0)
+	for _, node := range root.Resolved() {
+		resolved = append(resolved, node.String())
+	}
+	a.Equal([]string{
+		"com.example:a:jar:1:compile",
+		// dependencyManagement of the project wins over what a asks for
+		"com.example:c:jar:2:compile",
+		"com.example:r:jar:1:runtime",
+		"com.example:t:jar:1:test",
+		// b needs h for compile, so it is widened from test
+		"com.example:h:jar:1:compile",
+		"com.example:b:jar:1:compile",
+		"com.example:f:jar:1.5:compile",
+	}, resolved)
+
+	c := root.Children[0].Children[0]
+	a.Equal("1", c.PremanagedVersion)
+	h := root.Children[1].Children[0]
+	a.Equal("test", h.ScopeUpdatedFrom)
+	a.Equal(root.Children[0], c.Parent())
+
+	// b's own dependencies on c and h lost to the ones that were found first
+	b := root.Children[2]
+	a.Len(b.Children, 3)
+	a.Equal("c", b.Children[0].ArtifactID)
+	a.Equal(c, b.Children[0].OmittedFor)
+	a.Equal("2", b.Children[0].Version)
+	a.Equal("3", b.Children[0].PremanagedVersion)
+	a.Equal("f", b.Children[1].ArtifactID)
+	a.Nil(b.Children[1].OmittedFor)
+	a.Equal(h, b.Children[2].OmittedFor)
+}
+
+func TestDependencyResolverMissing(t *testing.T) {
+	a := assert.New(t)
+	dir, err := ioutil.TempDir("", "pom")
+	a.NoError(err)
+	defer os.RemoveAll(dir)
+
+	model, err := Unmarshal([]byte(dependencyProjectPom))
+	a.NoError(err)
+	_, err = NewDependencyResolver(dir).Resolve(model)
+	a.Equal(&NotFoundError{GroupID: "com.example", ArtifactID: "a", Version: "1"}, err)
+}
+
+func TestDependencyResolverIntermediateManagement(t *testing.T) {
+	a := assert.New(t)
+	dir, err := ioutil.TempDir("", "pom")
+	a.NoError(err)
+	defer os.RemoveAll(dir)
+	a.NoError(writeRepository(dir, map[string]string{
+		// m manages c, which only its own dependency n brings in
+		"com.example:m:1": `<project>
+    <groupId>com.example</groupId>
+    <artifactId>m</artifactId>
+    <version>1</version>
+    <dependencyManagement>
+        <dependencies>
+            <dependency><groupId>com.example</groupId><artifactId>c</artifactId><version>3</version></dependency>
+        </dependencies>
+    </dependencyManagement>
+    <dependencies>
+        <dependency><groupId>com.example</groupId><artifactId>n</artifactId><version>1</version></dependency>
+    </dependencies>
+</project>`,
+		"com.example:n:1": `<project>
+    <groupId>com.example</groupId>
+    <artifactId>n</artifactId>
+    <version>1</version>
+    <dependencies>
+        <dependency><groupId>com.example</groupId><artifactId>c</artifactId><version>1</version></dependency>
+    </dependencies>
+</project>`,
+		"com.example:c:1": leafPom("c", "1"),
+		"com.example:c:3": leafPom("c", "3"),
+	}))
+
+	model, err := Unmarshal([]byte(`<project>
+    <groupId>com.example</groupId>
+    <artifactId>app</artifactId>
+    <version>1</version>
+    <dependencies>
+        <dependency><groupId>com.example</groupId><artifactId>m</artifactId><version>1</version></dependency>
+    </dependencies>
+</project>`))
+	a.NoError(err)
+	root, err := NewDependencyResolver(dir).Resolve(model)
+	a.NoError(err)
+
+	// Only the project's own dependencyManagement applies, the same as in Maven 3
+	c := root.Children[0].Children[0].Children[0]
+	a.Equal("com.example:c:jar:1:compile", c.String())
+	a.Empty(c.PremanagedVersion)
+}
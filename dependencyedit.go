@@ -0,0 +1,255 @@
+package pom
+
+import (
+	"fmt"
+	"strings"
+)
+
+// VersionOptions choose where SetDependencyVersion writes a version
+type VersionOptions struct {
+	// Property puts the version in this property, and has the dependency refer to it as ${Property}
+	Property string
+	// Managed moves the version into dependencyManagement, leaving the dependency without one.
+	// Only a Model or Profile has a dependencyManagement to move it to.
+	Managed bool
+}
+
+// dependencyList is somewhere dependencies are declared, along with the
+// properties and dependencyManagement next to it.  Either of those may be nil.
+type dependencyList struct {
+	dependencies **SequenceDependency
+	properties   **XMLProperties
+	management   **DependencyManagement
+}
+
+// sameArtifact compares the groupId, artifactId, type and classifier of two coordinates
+func sameArtifact(a, b Coordinates) bool {
+	return a.GroupID == b.GroupID && a.ArtifactID == b.ArtifactID &&
+		a.extensionType() == b.extensionType() && a.Classifier == b.Classifier
+}
+
+func findDependency(dependencies *SequenceDependency, c Coordinates) (int, *Dependency) {
+	if dependencies == nil {
+		return -1, nil
+	}
+	for i, d := range dependencies.Dependency {
+		if d != nil && sameArtifact(d.Coordinates(), c) {
+			return i, d
+		}
+	}
+	return -1, nil
+}
+
+func (l dependencyList) find(c Coordinates) *Dependency {
+	_, d := findDependency(*l.dependencies, c)
+	return d
+}
+
+func (l dependencyList) findManaged(c Coordinates) *Dependency {
+	if l.management == nil || *l.management == nil {
+		return nil
+	}
+	_, d := findDependency((*l.management).Dependencies, c)
+	return d
+}
+
+func (l dependencyList) upsert(d *Dependency) *Dependency {
+	existing := l.find(d.Coordinates())
+	if existing == nil {
+		if *l.dependencies == nil {
+			*l.dependencies = &SequenceDependency{}
+		}
+		(*l.dependencies).Dependency = append((*l.dependencies).Dependency, d)
+		return d
+	}
+	existing.Version = firstString(d.Version, existing.Version)
+	existing.Scope = firstString(d.Scope, existing.Scope)
+	existing.SystemPath = firstString(d.SystemPath, existing.SystemPath)
+	existing.Optional = firstString(d.Optional, existing.Optional)
+	if d.Exclusions != nil {
+		existing.Exclusions = d.Exclusions
+	}
+	return existing
+}
+
+func (l dependencyList) remove(c Coordinates) bool {
+	i, d := findDependency(*l.dependencies, c)
+	if d == nil {
+		return false
+	}
+	dependencies := *l.dependencies
+	dependencies.Dependency = append(dependencies.Dependency[:i], dependencies.Dependency[i+1:]...)
+	if len(dependencies.Dependency) == 0 {
+		*l.dependencies = nil
+	}
+	return true
+}
+
+func (l dependencyList) setVersion(c Coordinates, version string, options []VersionOptions) error {
+	var option VersionOptions
+	if len(options) > 0 {
+		option = options[0]
+	}
+	d, managed := l.find(c), l.findManaged(c)
+	if d == nil && managed == nil {
+		return fmt.Errorf("pom: there is no dependency on %s", c)
+	}
+
+	target := d
+	switch {
+	case option.Managed:
+		if l.management == nil {
+			return fmt.Errorf("pom: there is no dependencyManagement to move the version of %s into", c)
+		}
+		if managed == nil {
+			if *l.management == nil {
+				*l.management = &DependencyManagement{}
+			}
+			managed = &Dependency{GroupID: d.GroupID, ArtifactID: d.ArtifactID, Type: d.Type, Classifier: d.Classifier}
+			managed = (*l.management).dependencyList().upsert(managed)
+		}
+		if d != nil {
+			d.Version = nil
+		}
+		target = managed
+	case d == nil || (d.Version == nil && managed != nil):
+		// The version comes from dependencyManagement, so that is where it changes
+		target = managed
+	}
+
+	if option.Property != "" {
+		if l.properties == nil {
+			return fmt.Errorf("pom: there are no properties to put the version of %s in", c)
+		}
+		if *l.properties == nil {
+			*l.properties = &XMLProperties{}
+		}
+		(*l.properties).Set(option.Property, version)
+		target.SetVersion("${" + option.Property + "}")
+		return nil
+	}
+	current := trimmed(target.Version)
+	if l.properties != nil && strings.HasPrefix(current, "${") && strings.HasSuffix(current, "}") && !versionExpressions[current] {
+		name := current[2 : len(current)-1]
+		if _, ok := (*l.properties).Get(name); ok {
+			(*l.properties).Set(name, version)
+			return nil
+		}
+	}
+	target.SetVersion(version)
+	return nil
+}
+
+func (a *Model) dependencyList() dependencyList {
+	return dependencyList{dependencies: &a.Dependencies, properties: &a.Properties, management: &a.DependencyManagement}
+}
+
+// FindDependency returns the dependency on c, or nil if there is none.
+// Dependencies are matched on their groupId, artifactId, type and classifier.
+func (a *Model) FindDependency(c Coordinates) *Dependency {
+	return a.dependencyList().find(c)
+}
+
+// UpsertDependency adds d, or if there is already a dependency on the same
+// artifact, copies everything d sets onto it.  It returns the dependency in the model.
+// Usage:
+//   model.UpsertDependency(pom.Coordinates{GroupID: "org.slf4j", ArtifactID: "slf4j-api", Version: "1.7.30"}.Dependency())
+func (a *Model) UpsertDependency(d *Dependency) *Dependency {
+	return a.dependencyList().upsert(d)
+}
+
+// RemoveDependency removes the dependency on c, reporting whether there was one
+func (a *Model) RemoveDependency(c Coordinates) bool {
+	return a.dependencyList().remove(c)
+}
+
+// SetDependencyVersion changes the version of the dependency on c.
+// If the version comes from dependencyManagement it is changed there, and if it
+// refers to a property of the model, the property is changed instead.
+// options can move the version into a property or into dependencyManagement.
+// Usage:
+//   err := model.SetDependencyVersion(c, "1.7.30", pom.VersionOptions{Property: "slf4j.version"})
+func (a *Model) SetDependencyVersion(c Coordinates, version string, options ...VersionOptions) error {
+	return a.dependencyList().setVersion(c, version, options)
+}
+
+func (a *Profile) dependencyList() dependencyList {
+	return dependencyList{dependencies: &a.Dependencies, properties: &a.Properties, management: &a.DependencyManagement}
+}
+
+// FindDependency returns the dependency on c, or nil if there is none.
+// Dependencies are matched on their groupId, artifactId, type and classifier.
+func (a *Profile) FindDependency(c Coordinates) *Dependency {
+	return a.dependencyList().find(c)
+}
+
+// UpsertDependency adds d, or if there is already a dependency on the same
+// artifact, copies everything d sets onto it.  It returns the dependency in the profile.
+func (a *Profile) UpsertDependency(d *Dependency) *Dependency {
+	return a.dependencyList().upsert(d)
+}
+
+// RemoveDependency removes the dependency on c, reporting whether there was one
+func (a *Profile) RemoveDependency(c Coordinates) bool {
+	return a.dependencyList().remove(c)
+}
+
+// SetDependencyVersion changes the version of the dependency on c, the same
+// way as Model.SetDependencyVersion, using the profile's own properties and dependencyManagement
+func (a *Profile) SetDependencyVersion(c Coordinates, version string, options ...VersionOptions) error {
+	return a.dependencyList().setVersion(c, version, options)
+}
+
+func (a *DependencyManagement) dependencyList() dependencyList {
+	return dependencyList{dependencies: &a.Dependencies}
+}
+
+// FindDependency returns the managed dependency on c, or nil if there is none.
+// Dependencies are matched on their groupId, artifactId, type and classifier.
+func (a *DependencyManagement) FindDependency(c Coordinates) *Dependency {
+	return a.dependencyList().find(c)
+}
+
+// UpsertDependency adds d, or if the artifact is already managed, copies
+// everything d sets onto the existing entry.  It returns the entry.
+func (a *DependencyManagement) UpsertDependency(d *Dependency) *Dependency {
+	return a.dependencyList().upsert(d)
+}
+
+// RemoveDependency removes the managed dependency on c, reporting whether there was one
+func (a *DependencyManagement) RemoveDependency(c Coordinates) bool {
+	return a.dependencyList().remove(c)
+}
+
+// SetDependencyVersion changes the version of the managed dependency on c.
+// There are no properties or dependencyManagement here for options to move the version into.
+func (a *DependencyManagement) SetDependencyVersion(c Coordinates, version string, options ...VersionOptions) error {
+	return a.dependencyList().setVersion(c, version, options)
+}
+
+func (a *Plugin) dependencyList() dependencyList {
+	return dependencyList{dependencies: &a.Dependencies}
+}
+
+// FindDependency returns the plugin's dependency on c, or nil if there is none.
+// Dependencies are matched on their groupId, artifactId, type and classifier.
+func (a *Plugin) FindDependency(c Coordinates) *Dependency {
+	return a.dependencyList().find(c)
+}
+
+// UpsertDependency adds d to the plugin, or if it already depends on the
+// artifact, copies everything d sets onto that dependency.  It returns the dependency in the plugin.
+func (a *Plugin) UpsertDependency(d *Dependency) *Dependency {
+	return a.dependencyList().upsert(d)
+}
+
+// RemoveDependency removes the plugin's dependency on c, reporting whether there was one
+func (a *Plugin) RemoveDependency(c Coordinates) bool {
+	return a.dependencyList().remove(c)
+}
+
+// SetDependencyVersion changes the version of the plugin's dependency on c.
+// There are no properties or dependencyManagement here for options to move the version into.
+func (a *Plugin) SetDependencyVersion(c Coordinates, version string, options ...VersionOptions) error {
+	return a.dependencyList().setVersion(c, version, options)
+}
@@ -0,0 +1,124 @@
+package pom
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var editPom = `<project>
+    <groupId>com.example</groupId>
+    <artifactId>edit</artifactId>
+    <version>1</version>
+    <properties>
+        <guava.version>28.0-jre</guava.version>
+    </properties>
+    <dependencyManagement>
+        <dependencies>
+            <dependency>
+                <groupId>io.netty</groupId>
+                <artifactId>netty-all</artifactId>
+                <version>4.1.42.Final</version>
+            </dependency>
+        </dependencies>
+    </dependencyManagement>
+    <dependencies>
+        <dependency>
+            <groupId>com.google.guava</groupId>
+            <artifactId>guava</artifactId>
+            <version>${guava.version}</version>
+        </dependency>
+        <dependency>
+            <groupId>io.netty</groupId>
+            <artifactId>netty-all</artifactId>
+        </dependency>
+        <dependency>
+            <groupId>org.slf4j</groupId>
+            <artifactId>slf4j-api</artifactId>
+            <version>1.7.28</version>
+        </dependency>
+        <dependency>
+            <groupId>com.example</groupId>
+            <artifactId>lib</artifactId>
+            <version>1</version>
+            <type>test-jar</type>
+        </dependency>
+    </dependencies>
+</project>`
+
+func TestFindDependency(t *testing.T) {
+	a := assert.New(t)
+	model, err := Unmarshal([]byte(editPom))
+	a.NoError(err)
+
+	a.Equal(model.Dependencies.Dependency[2], model.FindDependency(Coordinates{GroupID: "org.slf4j", ArtifactID: "slf4j-api"}))
+	a.Equal(model.Dependencies.Dependency[2], model.FindDependency(Coordinates{GroupID: "org.slf4j", ArtifactID: "slf4j-api", Type: "jar"}))
+	a.Nil(model.FindDependency(Coordinates{GroupID: "org.slf4j", ArtifactID: "slf4j-api", Classifier: "sources"}))
+	a.Nil(model.FindDependency(Coordinates{GroupID: "com.example", ArtifactID: "lib"}), "the type has to match")
+	a.NotNil(model.FindDependency(Coordinates{GroupID: "com.example", ArtifactID: "lib", Type: "test-jar"}))
+	a.NotNil(model.DependencyManagement.FindDependency(Coordinates{GroupID: "io.netty", ArtifactID: "netty-all"}))
+}
+
+func TestUpsertAndRemoveDependency(t *testing.T) {
+	a := assert.New(t)
+	model, err := Unmarshal([]byte(editPom))
+	a.NoError(err)
+
+	existing := model.UpsertDependency(&Dependency{GroupID: optional("org.slf4j"), ArtifactID: optional("slf4j-api"), Scope: optional("provided")})
+	a.Equal(model.Dependencies.Dependency[2], existing)
+	a.Equal("1.7.28", *existing.Version, "the version is kept when it is not given")
+	a.Equal("provided", *existing.Scope)
+
+	added := model.UpsertDependency(Coordinates{GroupID: "junit", ArtifactID: "junit", Version: "4.12"}.Dependency())
+	a.Len(model.Dependencies.Dependency, 5)
+	a.Equal(added, model.Dependencies.Dependency[4])
+
+	a.True(model.RemoveDependency(Coordinates{GroupID: "junit", ArtifactID: "junit"}))
+	a.False(model.RemoveDependency(Coordinates{GroupID: "junit", ArtifactID: "junit"}))
+	a.Len(model.Dependencies.Dependency, 4)
+
+	plugin := &Plugin{}
+	plugin.UpsertDependency(Coordinates{GroupID: "org.ow2.asm", ArtifactID: "asm", Version: "7.2"}.Dependency())
+	a.True(plugin.RemoveDependency(Coordinates{GroupID: "org.ow2.asm", ArtifactID: "asm"}))
+	a.Nil(plugin.Dependencies, "an empty list is removed")
+}
+
+func TestSetDependencyVersion(t *testing.T) {
+	a := assert.New(t)
+	doc, err := UnmarshalDocument([]byte(editPom))
+	a.NoError(err)
+	model := &doc.Model
+
+	// The version is kept in a property, so that is what changes
+	a.NoError(model.SetDependencyVersion(Coordinates{GroupID: "com.google.guava", ArtifactID: "guava"}, "29.0-jre"))
+	a.Equal("29.0-jre", model.Properties.Elements[0].Value)
+	a.Equal("${guava.version}", *model.Dependencies.Dependency[0].Version)
+
+	// The version comes from dependencyManagement
+	a.NoError(model.SetDependencyVersion(Coordinates{GroupID: "io.netty", ArtifactID: "netty-all"}, "4.1.50.Final"))
+	a.Equal("4.1.50.Final", *model.DependencyManagement.Dependencies.Dependency[0].Version)
+	a.Nil(model.Dependencies.Dependency[1].Version)
+
+	slf4j := Coordinates{GroupID: "org.slf4j", ArtifactID: "slf4j-api"}
+	a.NoError(model.SetDependencyVersion(slf4j, "1.7.30", VersionOptions{Property: "slf4j.version", Managed: true}))
+	a.Nil(model.Dependencies.Dependency[2].Version)
+	managed := model.DependencyManagement.FindDependency(slf4j)
+	a.Equal("${slf4j.version}", *managed.Version)
+
+	data, err := MarshalDocument(doc)
+	a.NoError(err)
+	pom := string(data)
+	a.Contains(pom, "<guava.version>29.0-jre</guava.version>")
+	a.Contains(pom, "<slf4j.version>1.7.30</slf4j.version>")
+	a.Contains(pom, "<version>4.1.50.Final</version>")
+	a.Equal(2, strings.Count(pom, "<artifactId>slf4j-api</artifactId>"))
+	a.NotContains(pom, "1.7.28")
+
+	a.EqualError(model.SetDependencyVersion(Coordinates{GroupID: "junit", ArtifactID: "junit"}, "4.12"), "pom: there is no dependency on junit:junit")
+	plugin := &Plugin{}
+	plugin.UpsertDependency(Coordinates{GroupID: "org.ow2.asm", ArtifactID: "asm", Version: "7.2"}.Dependency())
+	a.Error(plugin.SetDependencyVersion(Coordinates{GroupID: "org.ow2.asm", ArtifactID: "asm"}, "8.0", VersionOptions{Property: "asm.version"}))
+	a.NoError(plugin.SetDependencyVersion(Coordinates{GroupID: "org.ow2.asm", ArtifactID: "asm"}, "8.0"))
+	a.Equal("8.0", *plugin.Dependencies.Dependency[0].Version)
+}
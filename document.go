@@ -0,0 +1,534 @@
+package pom
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Document is a POM that remembers exactly how it was written.
+// Marshalling a Document only rewrites the parts of the file whose values
+// changed in Model, so whitespace, element order, attribute quoting and
+// comments everywhere else are left exactly as they were.
+type Document struct {
+	Model Model
+
+	raw  []byte
+	tree *node
+	// original is the Model as it was when the document was read, in the form
+	// encoding/xml writes it.  Comparing it with the current Model tells us what changed.
+	original *node
+	// positions is where everything was when the document was read
+	positions *Positions
+	// charset is what the document was read in, and so what it is written back out in.
+	// raw is always UTF-8.
+	charset string
+}
+
+// UnmarshalDocument takes in the raw data of a POM, and returns a Document
+// that can be written back out with MarshalDocument without losing any formatting
+func UnmarshalDocument(rawPom []byte) (*Document, error) {
+	data, charset, err := toUTF8(rawPom)
+	if err != nil {
+		return nil, err
+	}
+	model, err := unmarshalUTF8("", data)
+	if err != nil {
+		return nil, err
+	}
+	model.Encoding = charset
+	tree, err := parseTree(data)
+	if err != nil {
+		return nil, err
+	}
+	if firstElement(tree) == nil {
+		return nil, errors.New("pom: document has no project element")
+	}
+	original, err := modelTree(model)
+	if err != nil {
+		return nil, err
+	}
+	doc := &Document{
+		Model:    model,
+		raw:      append([]byte{}, data...),
+		tree:     tree,
+		original: original,
+		charset:  charset,
+	}
+	doc.positions, err = readPositions(data, &doc.Model)
+	if err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// Positions returns where each element of the document was when it was read.
+// Pointers in doc.Model can be looked up with Of for as long as they are kept.
+func (doc *Document) Positions() *Positions {
+	return doc.positions
+}
+
+// MarshalDocument writes a Document back out.  Anything that has not been changed
+// in doc.Model since it was read is returned byte for byte.
+// It fails if a change is to elements the document can not be lined up with,
+// like a list written twice that encoding/xml read as one.
+func MarshalDocument(doc *Document) ([]byte, error) {
+	if doc.tree == nil {
+		// Not read from anywhere, so there is no formatting to keep
+		return Marshal(doc.Model)
+	}
+	current, err := modelTree(doc.Model)
+	if err != nil {
+		return nil, err
+	}
+	p := &patcher{raw: doc.raw}
+	p.newline = "\n"
+	if bytes.Contains(doc.raw, []byte("\r\n")) {
+		p.newline = "\r\n"
+	}
+	p.unit = p.detectIndentUnit(firstElement(doc.tree))
+	p.element(firstElement(doc.tree), doc.original, current)
+	if p.err != nil {
+		return nil, p.err
+	}
+	return fromUTF8(p.apply(), doc.charset)
+}
+
+// modelTree marshals a model with encoding/xml, and returns the project element
+func modelTree(model Model) (*node, error) {
+	data, err := xml.Marshal(project{model})
+	if err != nil {
+		return nil, err
+	}
+	tree, err := parseTree(data)
+	if err != nil {
+		return nil, err
+	}
+	return firstElement(tree), nil
+}
+
+// patch replaces raw[start:end] with text
+type patch struct {
+	start, end int
+	text       string
+}
+
+// patcher works out the smallest set of patches needed to turn the original
+// document into one matching the current model
+type patcher struct {
+	raw     []byte
+	patches []patch
+	newline string
+	unit    string
+	// err is a change that could not be made
+	err error
+}
+
+// apply returns raw with all of the patches applied
+func (p *patcher) apply() []byte {
+	// Stable, so that several insertions at the same place keep their order
+	sort.SliceStable(p.patches, func(i, j int) bool {
+		return p.patches[i].start < p.patches[j].start
+	})
+	out := &bytes.Buffer{}
+	last := 0
+	for _, patch := range p.patches {
+		out.Write(p.raw[last:patch.start])
+		out.WriteString(patch.text)
+		last = patch.end
+	}
+	out.Write(p.raw[last:])
+	return out.Bytes()
+}
+
+func (p *patcher) replace(start, end int, text string) {
+	p.patches = append(p.patches, patch{start: start, end: end, text: text})
+}
+
+// element updates d, the element in the document, to reflect the changes
+// between before and after
+func (p *patcher) element(d, before, after *node) {
+	if sameNode(before, after) {
+		return
+	}
+	p.attributes(d, before, after)
+
+	if !before.hasElements() && !after.hasElements() && !d.hasElements() {
+		if before.textContent() != after.textContent() {
+			p.setContent(d, escapeText(after.textContent()))
+		}
+		return
+	}
+	if !after.hasElements() {
+		// Children were replaced with plain text
+		p.setContent(d, escapeText(after.textContent()))
+		return
+	}
+	p.children(d, before, after)
+}
+
+// setContent replaces everything between the start and end tags of d
+func (p *patcher) setContent(d *node, content string) {
+	if d.selfClosing {
+		if content == "" {
+			return
+		}
+		startTag := strings.TrimSuffix(strings.TrimRight(strings.TrimSuffix(string(p.raw[d.start:d.end]), "/>"), " \t\r\n"), "/")
+		p.replace(d.start, d.end, startTag+">"+content+"</"+qualifiedName(d.name)+">")
+		return
+	}
+	p.replace(d.innerStart, d.innerEnd, content)
+}
+
+// children lines up the child elements of before and after, and updates,
+// inserts or removes the matching children of d
+func (p *patcher) children(d, before, after *node) {
+	// Everything is matched up by element name.  The nth <dependency> in the
+	// model is always the nth <dependency> in the document.
+	names := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, c := range append(before.elements(), after.elements()...) {
+		if !seen[c.name.Local] {
+			seen[c.name.Local] = true
+			names = append(names, c.name.Local)
+		}
+	}
+
+	// placed maps the children of after to the document element they ended up as,
+	// so new elements can be inserted next to their siblings
+	placed := make(map[*node]*node)
+	inserts := make(map[*node]bool)
+	for _, name := range names {
+		befores := namedElements(before, name)
+		afters := namedElements(after, name)
+		docs := namedElements(d, name)
+		if len(docs) > len(befores) && len(befores) == 1 {
+			// encoding/xml keeps the last value of a repeated element
+			docs = docs[len(docs)-1:]
+		}
+		if len(docs) != len(befores) {
+			// The document can not be lined up with the model, like when encoding/xml
+			// joined two lists into one, so changes to these elements have nowhere to go
+			if !sameNodes(befores, afters) && p.err == nil {
+				p.err = fmt.Errorf("pom: cannot write the changes to <%s> in %s, as the document has %d of them and the model %d",
+					name, d.path(), len(docs), len(befores))
+			}
+			continue
+		}
+		for _, step := range alignNodes(befores, afters) {
+			switch {
+			case step.before >= 0 && step.after >= 0:
+				p.element(docs[step.before], befores[step.before], afters[step.after])
+				placed[afters[step.after]] = docs[step.before]
+			case step.before >= 0:
+				p.remove(docs[step.before])
+			case step.after >= 0:
+				inserts[afters[step.after]] = true
+			}
+		}
+	}
+
+	afterElements := after.elements()
+	if lastElement(d) == nil {
+		// Nothing to line up with, so all of the new elements become the content of d
+		indent := p.indentOf(d)
+		text := ""
+		for _, c := range afterElements {
+			if inserts[c] {
+				text += p.newline + indent + p.unit + p.render(c, indent+p.unit)
+			}
+		}
+		if text == "" {
+			return
+		}
+		if d.selfClosing {
+			p.setContent(d, text+p.newline+indent)
+			return
+		}
+		// Keep any comments, but replace trailing whitespace with our own
+		trimmed := bytes.TrimRight(p.raw[d.innerStart:d.innerEnd], " \t\r\n")
+		p.replace(d.innerStart+len(trimmed), d.innerEnd, text+p.newline+indent)
+		return
+	}
+	for i, c := range afterElements {
+		if !inserts[c] {
+			continue
+		}
+		// Insert after the closest earlier sibling that is in the document,
+		// or before the closest later one.  Failing both, it goes at the end.
+		var anchor *node
+		anchorAfter := true
+		for j := i - 1; j >= 0 && anchor == nil; j-- {
+			anchor = placed[afterElements[j]]
+		}
+		for j := i + 1; j < len(afterElements) && anchor == nil; j++ {
+			anchor, anchorAfter = placed[afterElements[j]], false
+		}
+		if anchor == nil {
+			anchor, anchorAfter = lastElement(d), true
+		}
+		indent := p.indentOf(anchor)
+		text := p.render(c, indent)
+		if anchorAfter {
+			p.replace(anchor.end, anchor.end, p.newline+indent+text)
+		} else {
+			p.replace(anchor.start, anchor.start, text+p.newline+indent)
+		}
+	}
+}
+
+// remove deletes an element from the document, along with the indentation in front of it
+func (p *patcher) remove(d *node) {
+	start := d.start
+	for start > 0 && (p.raw[start-1] == ' ' || p.raw[start-1] == '\t') {
+		start--
+	}
+	if start > 0 && p.raw[start-1] == '\n' {
+		start--
+		if start > 0 && p.raw[start-1] == '\r' {
+			start--
+		}
+	} else {
+		start = d.start
+	}
+	p.replace(start, d.end, "")
+}
+
+// attributes updates the attributes in the start tag of d in place, keeping
+// the quoting and spacing of anything that did not change
+func (p *patcher) attributes(d, before, after *node) {
+	changed := false
+	if len(before.attrs) != len(after.attrs) {
+		changed = true
+	}
+	for i := 0; !changed && i < len(before.attrs); i++ {
+		changed = before.attrs[i] != after.attrs[i]
+	}
+	if !changed {
+		return
+	}
+
+	spans := scanAttributes(p.raw, d.start, d.innerStart)
+	quote := byte('"')
+	if len(spans) > 0 {
+		quote = spans[0].quote
+	}
+	lookup := func(attrs []xml.Attr, name string) (string, bool) {
+		for _, attr := range attrs {
+			if qualifiedName(attr.Name) == name {
+				return attr.Value, true
+			}
+		}
+		return "", false
+	}
+	for _, span := range spans {
+		_, wasSet := lookup(before.attrs, span.name)
+		value, isSet := lookup(after.attrs, span.name)
+		if !wasSet {
+			// Something the model does not know about, like xmlns
+			continue
+		}
+		if !isSet {
+			p.replace(span.start, span.end, "")
+		} else if oldValue, _ := lookup(before.attrs, span.name); oldValue != value {
+			p.replace(span.valueStart, span.valueEnd, escapeAttr(value, span.quote))
+		}
+	}
+	// New attributes go after the last one in the tag
+	end := d.start + 1 + len(qualifiedName(d.name))
+	if len(spans) > 0 {
+		end = spans[len(spans)-1].end
+	}
+	for _, attr := range after.attrs {
+		name := qualifiedName(attr.Name)
+		if _, wasSet := lookup(before.attrs, name); wasSet {
+			continue
+		}
+		p.replace(end, end, " "+name+"="+string(quote)+escapeAttr(attr.Value, quote)+string(quote))
+	}
+}
+
+// attributeSpan is the location of a single attribute inside a start tag
+type attributeSpan struct {
+	name                 string
+	start, end           int
+	valueStart, valueEnd int
+	quote                byte
+}
+
+// scanAttributes finds each attribute in the start tag raw[start:end]
+func scanAttributes(raw []byte, start, end int) []attributeSpan {
+	spans := make([]attributeSpan, 0)
+	i := start + 1
+	// Skip the element name
+	for i < end && !isSpace(raw[i]) && raw[i] != '>' && raw[i] != '/' {
+		i++
+	}
+	for i < end {
+		spanStart := i
+		for i < end && isSpace(raw[i]) {
+			i++
+		}
+		nameStart := i
+		for i < end && !isSpace(raw[i]) && raw[i] != '=' && raw[i] != '>' && raw[i] != '/' {
+			i++
+		}
+		if i == nameStart {
+			break
+		}
+		name := string(raw[nameStart:i])
+		for i < end && (isSpace(raw[i]) || raw[i] == '=') {
+			i++
+		}
+		if i >= end || (raw[i] != '"' && raw[i] != '\'') {
+			break
+		}
+		quote := raw[i]
+		i++
+		valueStart := i
+		for i < end && raw[i] != quote {
+			i++
+		}
+		spans = append(spans, attributeSpan{
+			name:       name,
+			start:      spanStart,
+			end:        i + 1,
+			valueStart: valueStart,
+			valueEnd:   i,
+			quote:      quote,
+		})
+		i++
+	}
+	return spans
+}
+
+func isSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\r' || b == '\n'
+}
+
+// render formats a new element to sit in the document at the given indentation
+func (p *patcher) render(n *node, indent string) string {
+	b := &strings.Builder{}
+	n.render(b, indent, p.unit, p.newline)
+	return b.String()
+}
+
+// indentOf returns the whitespace between the start of the line and n
+func (p *patcher) indentOf(n *node) string {
+	i := n.start
+	for i > 0 && (p.raw[i-1] == ' ' || p.raw[i-1] == '\t') {
+		i--
+	}
+	return string(p.raw[i:n.start])
+}
+
+// detectIndentUnit works out how far the document indents each level, using
+// the first child of the root element.  Four spaces is used if we cannot tell.
+func (p *patcher) detectIndentUnit(root *node) string {
+	if first := firstElement(root); first != nil {
+		rootIndent, childIndent := p.indentOf(root), p.indentOf(first)
+		if strings.HasPrefix(childIndent, rootIndent) && len(childIndent) > len(rootIndent) {
+			return childIndent[len(rootIndent):]
+		}
+	}
+	return "    "
+}
+
+// namedElements returns the child elements of n with the given local name
+func namedElements(n *node, name string) []*node {
+	result := make([]*node, 0)
+	for _, c := range n.children {
+		if c.kind == elementNode && c.name.Local == name {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+func firstElement(n *node) *node {
+	for _, c := range n.children {
+		if c.kind == elementNode {
+			return c
+		}
+	}
+	return nil
+}
+
+func lastElement(n *node) *node {
+	for i := len(n.children) - 1; i >= 0; i-- {
+		if n.children[i].kind == elementNode {
+			return n.children[i]
+		}
+	}
+	return nil
+}
+
+// alignStep pairs up an index in before with an index in after.
+// An index of -1 means the element only exists on the other side.
+type alignStep struct {
+	before, after int
+}
+
+// alignNodes lines up two lists of elements, keeping identical elements
+// together (a longest common subsequence) and pairing up whatever is
+// left over between them in order
+func alignNodes(before, after []*node) []alignStep {
+	// lcs[i][j] is the length of the longest common subsequence of before[i:] and after[j:]
+	lcs := make([][]int, len(before)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(after)+1)
+	}
+	for i := len(before) - 1; i >= 0; i-- {
+		for j := len(after) - 1; j >= 0; j-- {
+			if sameNode(before[i], after[j]) {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	steps := make([]alignStep, 0)
+	var removed, added []int
+	flush := func() {
+		for len(removed) > 0 && len(added) > 0 {
+			steps = append(steps, alignStep{removed[0], added[0]})
+			removed, added = removed[1:], added[1:]
+		}
+		for _, i := range removed {
+			steps = append(steps, alignStep{i, -1})
+		}
+		for _, j := range added {
+			steps = append(steps, alignStep{-1, j})
+		}
+		removed, added = nil, nil
+	}
+	i, j := 0, 0
+	for i < len(before) && j < len(after) {
+		if sameNode(before[i], after[j]) {
+			flush()
+			steps = append(steps, alignStep{i, j})
+			i++
+			j++
+		} else if lcs[i+1][j] >= lcs[i][j+1] {
+			removed = append(removed, i)
+			i++
+		} else {
+			added = append(added, j)
+			j++
+		}
+	}
+	for ; i < len(before); i++ {
+		removed = append(removed, i)
+	}
+	for ; j < len(after); j++ {
+		added = append(added, j)
+	}
+	flush()
+	return steps
+}
@@ -0,0 +1,120 @@
+package pom
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocumentUnchangedIsIdentical(t *testing.T) {
+	a := assert.New(t)
+	doc, err := UnmarshalDocument([]byte(examplePom))
+	a.NoError(err, "Error unmarshalling test data")
+	rawPom, err := MarshalDocument(doc)
+	a.NoError(err, "Error marshalling test data")
+	a.Equal(examplePom, string(rawPom), "Untouched document was changed")
+}
+
+func TestDocumentOnlyChangesUpdatedValue(t *testing.T) {
+	a := assert.New(t)
+	doc, err := UnmarshalDocument([]byte(examplePom))
+	a.NoError(err, "Error unmarshalling test data")
+	doc.Model.SetVersion("0.4.23-SNAPSHOT")
+	rawPom, err := MarshalDocument(doc)
+	a.NoError(err, "Error marshalling test data")
+	expected := strings.Replace(examplePom, "<version>0.4.22-SNAPSHOT</version>", "<version>0.4.23-SNAPSHOT</version>", 1)
+	a.Equal(expected, string(rawPom), "Only the version should have changed")
+}
+
+func TestDocumentAddAndRemoveElements(t *testing.T) {
+	a := assert.New(t)
+	raw := "<?xml version='1.0' encoding='UTF-8'?>\n" +
+		"<project>\n" +
+		"  <!-- keep me -->\n" +
+		"  <artifactId>a</artifactId>\n" +
+		"  <dependencies>\n" +
+		"    <dependency>\n" +
+		"      <artifactId>one</artifactId>\n" +
+		"    </dependency>\n" +
+		"    <dependency>\n" +
+		"      <artifactId>two</artifactId>\n" +
+		"    </dependency>\n" +
+		"  </dependencies>\n" +
+		"  <modules/>\n" +
+		"</project>\n"
+	doc, err := UnmarshalDocument([]byte(raw))
+	a.NoError(err, "Error unmarshalling test data")
+
+	deps := doc.Model.Dependencies
+	deps.SetDependency(deps.GetDependency()[1:])
+	added := &Dependency{}
+	added.SetArtifactID("three")
+	deps.AddDependency(added)
+	module := "child"
+	doc.Model.Modules.AddModule(&module)
+
+	rawPom, err := MarshalDocument(doc)
+	a.NoError(err, "Error marshalling test data")
+	expected := "<?xml version='1.0' encoding='UTF-8'?>\n" +
+		"<project>\n" +
+		"  <!-- keep me -->\n" +
+		"  <artifactId>a</artifactId>\n" +
+		"  <dependencies>\n" +
+		"    <dependency>\n" +
+		"      <artifactId>two</artifactId>\n" +
+		"    </dependency>\n" +
+		"    <dependency>\n" +
+		"      <artifactId>three</artifactId>\n" +
+		"    </dependency>\n" +
+		"  </dependencies>\n" +
+		"  <modules>\n" +
+		"    <module>child</module>\n" +
+		"  </modules>\n" +
+		"</project>\n"
+	a.Equal(expected, string(rawPom), "Edits were not applied in place")
+}
+
+func TestDocumentKeepsAttributeQuoting(t *testing.T) {
+	a := assert.New(t)
+	raw := "<project xmlns='http://maven.apache.org/POM/4.0.0' child.project.url.inherit.append.path='true'>\n" +
+		"  <scm child.scm.url.inherit.append.path = 'true'/>\n" +
+		"</project>"
+	doc, err := UnmarshalDocument([]byte(raw))
+	a.NoError(err, "Error unmarshalling test data")
+	doc.Model.SetChildProjectURLInheritAppendPath("false")
+	doc.Model.Scm.SetChildScmConnectionInheritAppendPath("false")
+	rawPom, err := MarshalDocument(doc)
+	a.NoError(err, "Error marshalling test data")
+	expected := "<project xmlns='http://maven.apache.org/POM/4.0.0' child.project.url.inherit.append.path='false'>\n" +
+		"  <scm child.scm.url.inherit.append.path = 'true' child.scm.connection.inherit.append.path='false'/>\n" +
+		"</project>"
+	a.Equal(expected, string(rawPom), "Attributes were not updated in place")
+}
+
+func TestDocumentMismatchedElements(t *testing.T) {
+	a := assert.New(t)
+	// encoding/xml reads both lists into one, so the document has fewer dependencies in the last list than the model
+	raw := "<project>\n" +
+		"  <dependencies>\n" +
+		"    <dependency><artifactId>one</artifactId></dependency>\n" +
+		"  </dependencies>\n" +
+		"  <dependencies>\n" +
+		"    <dependency><artifactId>two</artifactId></dependency>\n" +
+		"  </dependencies>\n" +
+		"  <name>mismatched</name>\n" +
+		"</project>\n"
+	doc, err := UnmarshalDocument([]byte(raw))
+	a.NoError(err, "Error unmarshalling test data")
+	a.Len(doc.Model.Dependencies.Dependency, 2)
+
+	// Changes elsewhere are still written
+	doc.Model.SetName("matched")
+	rawPom, err := MarshalDocument(doc)
+	a.NoError(err)
+	a.Equal(strings.Replace(raw, "mismatched", "matched", 1), string(rawPom))
+
+	doc.Model.Dependencies.Dependency[1].SetVersion("1.0")
+	_, err = MarshalDocument(doc)
+	a.EqualError(err, "pom: cannot write the changes to <dependency> in project/dependencies[1], as the document has 1 of them and the model 2")
+}
@@ -0,0 +1,451 @@
+package pom
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+// Element is a single element of an XMLInner, such as <source> inside of a
+// plugin's <configuration>.  Anything that is not changed through an Element
+// is written back exactly as it was read, comments and whitespace included.
+//
+// Changes are written back to the XMLInner the Element came from as soon as they are made.
+type Element struct {
+	name   xml.Name
+	attrs  []xml.Attr
+	nodes  []*domNode
+	parent *Element
+	// inner is only set on the root Element, which stands in for the XMLInner itself
+	inner *XMLInner
+
+	// startTag and endTag are the tags as they were read.  startTag is cleared
+	// when the name or attributes change so that it gets rebuilt.
+	startTag string
+	endTag   string
+}
+
+// domNode is one piece of an Element's content: a child element, some text or a comment
+type domNode struct {
+	kind    nodeKind
+	element *Element
+	// raw is the node as it appears in the XML, already escaped
+	raw string
+}
+
+// DOM parses the XMLInner into a tree of Elements.  The returned Element stands
+// in for the element the XMLInner was read from (<configuration> for example),
+// so its children are the top level elements of the XMLInner.  A nil XMLInner,
+// like the configuration of a plugin that has none, gives a nil Element.
+// Usage:
+//   conf, err := plugin.Configuration.DOM()
+//   conf.Set("source", "11")
+func (a *XMLInner) DOM() (*Element, error) {
+	if a == nil {
+		return nil, nil
+	}
+	tree, err := parseTree([]byte(a.InnerXML))
+	if err != nil {
+		return nil, err
+	}
+	root := &Element{inner: a, attrs: a.Attrs}
+	root.nodes = buildDOM(root, tree, a.InnerXML)
+	return root, nil
+}
+
+// buildDOM converts the children of a parsed node into domNodes
+func buildDOM(parent *Element, n *node, raw string) []*domNode {
+	nodes := make([]*domNode, 0, len(n.children))
+	for _, c := range n.children {
+		switch c.kind {
+		case elementNode:
+			e := &Element{name: c.name, attrs: c.attrs, parent: parent}
+			if c.selfClosing {
+				e.startTag = raw[c.start:c.end]
+			} else {
+				e.startTag = raw[c.start:c.innerStart]
+				e.endTag = raw[c.innerEnd:c.end]
+			}
+			e.nodes = buildDOM(e, c, raw)
+			nodes = append(nodes, &domNode{kind: elementNode, element: e})
+		case textNode:
+			nodes = append(nodes, &domNode{kind: textNode, raw: raw[c.start:c.end]})
+		default:
+			nodes = append(nodes, &domNode{kind: c.kind, raw: raw[c.start:c.end]})
+		}
+	}
+	return nodes
+}
+
+// Name returns the name of the element, including any namespace prefix
+func (e *Element) Name() string {
+	return qualifiedName(e.name)
+}
+
+// Text returns the character data directly inside of the element
+func (e *Element) Text() string {
+	var b strings.Builder
+	for _, n := range e.nodes {
+		if n.kind == textNode {
+			b.WriteString(unescapeText(n.raw))
+		}
+	}
+	return b.String()
+}
+
+// SetText replaces the content of the element with value.
+// Child elements are removed, but comments are kept.
+func (e *Element) SetText(value string) {
+	nodes := make([]*domNode, 0, len(e.nodes)+1)
+	for _, n := range e.nodes {
+		if n.kind == commentNode {
+			nodes = append(nodes, n)
+		}
+	}
+	if value != "" {
+		nodes = append(nodes, &domNode{kind: textNode, raw: escapeText(value)})
+	}
+	e.nodes = nodes
+	e.changed()
+}
+
+// Attr returns the value of an attribute, and whether it was set at all
+func (e *Element) Attr(name string) (string, bool) {
+	for _, attr := range e.attrs {
+		if qualifiedName(attr.Name) == name {
+			return attr.Value, true
+		}
+	}
+	return "", false
+}
+
+// Attrs returns all of the attributes on the element, in order
+func (e *Element) Attrs() []xml.Attr {
+	return e.attrs
+}
+
+// SetAttr sets an attribute, adding it to the end if it is not already set
+func (e *Element) SetAttr(name, value string) {
+	attrs := make([]xml.Attr, 0, len(e.attrs)+1)
+	found := false
+	for _, attr := range e.attrs {
+		if qualifiedName(attr.Name) == name {
+			attr.Value = value
+			found = true
+		}
+		attrs = append(attrs, attr)
+	}
+	if !found {
+		attrs = append(attrs, xml.Attr{Name: splitName(name), Value: value})
+	}
+	e.attrs = attrs
+	e.startTag = ""
+	e.changed()
+}
+
+// RemoveAttr removes an attribute from the element
+func (e *Element) RemoveAttr(name string) {
+	attrs := make([]xml.Attr, 0, len(e.attrs))
+	for _, attr := range e.attrs {
+		if qualifiedName(attr.Name) != name {
+			attrs = append(attrs, attr)
+		}
+	}
+	e.attrs = attrs
+	e.startTag = ""
+	e.changed()
+}
+
+// Children returns the child elements, in document order
+func (e *Element) Children() []*Element {
+	result := make([]*Element, 0)
+	for _, n := range e.nodes {
+		if n.kind == elementNode {
+			result = append(result, n.element)
+		}
+	}
+	return result
+}
+
+// ChildrenNamed returns the child elements with the given name, in document order
+func (e *Element) ChildrenNamed(name string) []*Element {
+	result := make([]*Element, 0)
+	for _, child := range e.Children() {
+		if child.Name() == name {
+			result = append(result, child)
+		}
+	}
+	return result
+}
+
+// Child returns the first child element with the given name, or nil if there isn't one
+func (e *Element) Child(name string) *Element {
+	for _, child := range e.Children() {
+		if child.Name() == name {
+			return child
+		}
+	}
+	return nil
+}
+
+// Get follows a slash separated path of element names down from e, such as
+// "defaultOptions/bindingFiles".  nil is returned if any part of the path is missing.
+func (e *Element) Get(path string) *Element {
+	current := e
+	for _, name := range strings.Split(path, "/") {
+		if current = current.Child(name); current == nil {
+			return nil
+		}
+	}
+	return current
+}
+
+// Set sets the text of the element at path, creating any elements along the way that are missing
+func (e *Element) Set(path, value string) *Element {
+	current := e
+	for _, name := range strings.Split(path, "/") {
+		next := current.Child(name)
+		if next == nil {
+			next = current.AddChild(name)
+		}
+		current = next
+	}
+	current.SetText(value)
+	return current
+}
+
+// AddChild adds a new, empty element to the end of e and returns it.
+// It is indented to line up with its siblings.
+func (e *Element) AddChild(name string) *Element {
+	child := &Element{name: splitName(name), parent: e}
+	childNode := &domNode{kind: elementNode, element: child}
+
+	last := -1
+	for i, n := range e.nodes {
+		if n.kind == elementNode {
+			last = i
+		}
+	}
+	if last >= 0 {
+		// Copy the whitespace in front of the last sibling
+		indent := "\n" + e.indent() + e.indentUnit()
+		if last > 0 && e.nodes[last-1].kind == textNode && strings.TrimSpace(e.nodes[last-1].raw) == "" {
+			indent = e.nodes[last-1].raw
+		}
+		nodes := append([]*domNode{}, e.nodes[:last+1]...)
+		nodes = append(nodes, &domNode{kind: textNode, raw: indent}, childNode)
+		e.nodes = append(nodes, e.nodes[last+1:]...)
+	} else {
+		// The first child.  Any existing whitespace is replaced, since it was
+		// only there to line up the closing tag.
+		nodes := make([]*domNode, 0, len(e.nodes)+3)
+		for _, n := range e.nodes {
+			if n.kind != textNode || strings.TrimSpace(n.raw) != "" {
+				nodes = append(nodes, n)
+			}
+		}
+		indent := e.indent()
+		nodes = append(nodes,
+			&domNode{kind: textNode, raw: "\n" + indent + e.indentUnit()},
+			childNode,
+			&domNode{kind: textNode, raw: "\n" + indent})
+		e.nodes = nodes
+	}
+	e.changed()
+	return child
+}
+
+// RemoveChild removes the first child element with the given name.
+// It returns false if there was no such child.
+func (e *Element) RemoveChild(name string) bool {
+	child := e.Child(name)
+	if child == nil {
+		return false
+	}
+	child.Remove()
+	return true
+}
+
+// Remove takes the element out of its parent, along with the indentation in front of it
+func (e *Element) Remove() {
+	parent := e.parent
+	if parent == nil {
+		return
+	}
+	for i, n := range parent.nodes {
+		if n.element != e {
+			continue
+		}
+		start := i
+		if i > 0 && parent.nodes[i-1].kind == textNode && strings.TrimSpace(parent.nodes[i-1].raw) == "" {
+			// The whitespace in front of it was only there to indent it
+			start = i - 1
+		}
+		parent.nodes = append(parent.nodes[:start], parent.nodes[i+1:]...)
+		break
+	}
+	e.parent = nil
+	parent.changed()
+}
+
+// String returns the element as XML
+func (e *Element) String() string {
+	b := &strings.Builder{}
+	if e.inner != nil {
+		e.writeContent(b)
+	} else {
+		e.write(b)
+	}
+	return b.String()
+}
+
+func (e *Element) write(b *strings.Builder) {
+	startTag := e.startTag
+	if startTag == "" {
+		startTag = e.buildStartTag()
+	}
+	if len(e.nodes) == 0 && (e.endTag == "" || e.startTag == "") {
+		if !strings.HasSuffix(startTag, "/>") {
+			startTag = strings.TrimSuffix(startTag, ">") + "/>"
+		}
+		b.WriteString(startTag)
+		return
+	}
+	if strings.HasSuffix(startTag, "/>") {
+		// Was self closing, but has content now
+		startTag = strings.TrimRight(strings.TrimSuffix(startTag, "/>"), " \t\r\n") + ">"
+	}
+	b.WriteString(startTag)
+	e.writeContent(b)
+	if e.endTag != "" {
+		b.WriteString(e.endTag)
+	} else {
+		b.WriteString("</" + e.Name() + ">")
+	}
+}
+
+func (e *Element) writeContent(b *strings.Builder) {
+	for _, n := range e.nodes {
+		if n.kind == elementNode {
+			n.element.write(b)
+		} else {
+			b.WriteString(n.raw)
+		}
+	}
+}
+
+func (e *Element) buildStartTag() string {
+	b := &strings.Builder{}
+	b.WriteString("<" + e.Name())
+	for _, attr := range e.attrs {
+		b.WriteString(" " + qualifiedName(attr.Name) + `="` + escapeAttr(attr.Value, '"') + `"`)
+	}
+	b.WriteString(">")
+	return b.String()
+}
+
+// changed writes the whole tree back to the XMLInner it came from
+func (e *Element) changed() {
+	root := e
+	for root.parent != nil {
+		root = root.parent
+	}
+	if root.inner != nil {
+		root.inner.Attrs = root.attrs
+		root.inner.InnerXML = root.String()
+	}
+}
+
+// indent returns the whitespace in front of the element on its line.
+// For the root, this is taken from the whitespace in front of the closing tag.
+func (e *Element) indent() string {
+	var ws string
+	if e.parent == nil {
+		if len(e.nodes) > 0 && e.nodes[len(e.nodes)-1].kind == textNode {
+			ws = e.nodes[len(e.nodes)-1].raw
+		}
+	} else {
+		for i, n := range e.parent.nodes {
+			if n.element == e && i > 0 && e.parent.nodes[i-1].kind == textNode {
+				ws = e.parent.nodes[i-1].raw
+			}
+		}
+	}
+	if i := strings.LastIndex(ws, "\n"); i >= 0 && strings.TrimSpace(ws) == "" {
+		return ws[i+1:]
+	}
+	if e.parent != nil {
+		return e.parent.indent() + e.indentUnit()
+	}
+	return ""
+}
+
+// indentUnit works out how far each level is indented by comparing an
+// element with its first child.  Four spaces is used if we cannot tell.
+func (e *Element) indentUnit() string {
+	root := e
+	for root.parent != nil {
+		root = root.parent
+	}
+	var unit string
+	var find func(*Element) bool
+	find = func(current *Element) bool {
+		for _, child := range current.Children() {
+			if len(child.Children()) == 0 {
+				continue
+			}
+			parentIndent, childIndent := child.rawIndent(), child.Children()[0].rawIndent()
+			if strings.HasPrefix(childIndent, parentIndent) && len(childIndent) > len(parentIndent) {
+				unit = childIndent[len(parentIndent):]
+				return true
+			}
+			if find(child) {
+				return true
+			}
+		}
+		return false
+	}
+	if find(root) {
+		return unit
+	}
+	return "    "
+}
+
+// rawIndent is the indentation in front of the element as written, without any guessing
+func (e *Element) rawIndent() string {
+	for i, n := range e.parent.nodes {
+		if n.element == e && i > 0 && e.parent.nodes[i-1].kind == textNode {
+			ws := e.parent.nodes[i-1].raw
+			if j := strings.LastIndex(ws, "\n"); j >= 0 {
+				return ws[j+1:]
+			}
+		}
+	}
+	return ""
+}
+
+// splitName turns prefix:local into an xml.Name the way RawToken would
+func splitName(name string) xml.Name {
+	if i := strings.Index(name, ":"); i >= 0 {
+		return xml.Name{Space: name[:i], Local: name[i+1:]}
+	}
+	return xml.Name{Local: name}
+}
+
+// unescapeText decodes the character data of raw XML text
+func unescapeText(raw string) string {
+	if !strings.ContainsAny(raw, "&<") {
+		return raw
+	}
+	var text strings.Builder
+	d := xml.NewDecoder(strings.NewReader("<a>" + raw + "</a>"))
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			break
+		}
+		if data, ok := tok.(xml.CharData); ok {
+			text.Write(data)
+		}
+	}
+	return text.String()
+}
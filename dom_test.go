@@ -0,0 +1,85 @@
+package pom
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDOMSetText(t *testing.T) {
+	a := assert.New(t)
+	pom, err := Unmarshal([]byte(examplePom))
+	a.NoError(err, "Error unmarshalling test data")
+	compiler := pom.Build.Plugins.Plugin[0]
+	conf, err := compiler.Configuration.DOM()
+	a.NoError(err, "Error parsing configuration")
+	a.Equal("1.8", conf.Get("source").Text(), "Source was not read")
+	original := compiler.Configuration.InnerXML
+
+	conf.Set("source", "11")
+	a.Equal(strings.Replace(original, "<source>1.8</source>", "<source>11</source>", 1), compiler.Configuration.InnerXML, "Only the source should change")
+
+	args := conf.Get("compilerArgs").ChildrenNamed("arg")
+	a.Equal(2, len(args), "Wrong number of args")
+	a.Equal("-XDignore.symbol.file", args[1].Text())
+}
+
+func TestDOMAddAndRemove(t *testing.T) {
+	a := assert.New(t)
+	inner := &XMLInner{InnerXML: "\n" +
+		"    <!-- flags -->\n" +
+		"    <compilerArgs>\n" +
+		"        <arg>-Xlint</arg>\n" +
+		"    </compilerArgs>\n" +
+		"    <fork>true</fork>\n"}
+	conf, err := inner.DOM()
+	a.NoError(err, "Error parsing configuration")
+
+	conf.Get("compilerArgs").AddChild("arg").SetText("-Werror")
+	a.True(conf.RemoveChild("fork"), "fork was not removed")
+	conf.Set("release", "11").SetAttr("combine.self", "override")
+	conf.SetAttr("combine.children", "append")
+
+	expected := "\n" +
+		"    <!-- flags -->\n" +
+		"    <compilerArgs>\n" +
+		"        <arg>-Xlint</arg>\n" +
+		"        <arg>-Werror</arg>\n" +
+		"    </compilerArgs>\n" +
+		"    <release combine.self=\"override\">11</release>\n"
+	a.Equal(expected, inner.InnerXML, "DOM was not written back")
+	value, ok := conf.Attr("combine.children")
+	a.True(ok)
+	a.Equal("append", value)
+	a.Equal(1, len(inner.Attrs), "Attribute was not written back")
+}
+
+func TestDOMEmptyInner(t *testing.T) {
+	a := assert.New(t)
+	inner := &XMLInner{}
+	conf, err := inner.DOM()
+	a.NoError(err, "Error parsing configuration")
+	conf.Set("defaultOptions/extraargs/extraarg", "-xjc-npa")
+	a.Equal("\n    <defaultOptions>\n        <extraargs>\n            <extraarg>-xjc-npa</extraarg>\n        </extraargs>\n    </defaultOptions>\n", inner.InnerXML)
+}
+
+func TestDOMNilInner(t *testing.T) {
+	a := assert.New(t)
+	plugin := &Plugin{}
+	conf, err := plugin.Configuration.DOM()
+	a.NoError(err, "Missing configuration should not be an error")
+	a.Nil(conf, "Missing configuration should have no Element")
+}
+
+func TestDOMInDocument(t *testing.T) {
+	a := assert.New(t)
+	doc, err := UnmarshalDocument([]byte(examplePom))
+	a.NoError(err, "Error unmarshalling test data")
+	conf, err := doc.Model.Build.Plugins.Plugin[0].Configuration.DOM()
+	a.NoError(err, "Error parsing configuration")
+	conf.Set("target", "11")
+	rawPom, err := MarshalDocument(doc)
+	a.NoError(err, "Error marshalling test data")
+	a.Equal(strings.Replace(examplePom, "<target>1.8</target>", "<target>11</target>", 1), string(rawPom), "Only the target should change")
+}
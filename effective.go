@@ -0,0 +1,659 @@
+package pom
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Effective computes the effective model of a project by walking up through
+// its parents, and merging each of them in with Maven's inheritance rules.
+// BOMs imported in dependencyManagement are then expanded, see ManagedDependencies,
+// and the plugins of the build are merged with their pluginManagement.
+// Parents and BOMs are found through resolver.
+func Effective(model Model, resolver Resolver) (Model, error) {
+	return effective(model, "", resolver)
+}
+
+// EffectiveFile reads the POM at path and computes its effective model.
+// Parents are looked for on disk through their relativePath first, and then through resolver.
+// resolver may be nil if every parent is on disk.
+func EffectiveFile(path string, resolver Resolver) (Model, error) {
+	model, err := ReadFile(path)
+	if err != nil {
+		return model, err
+	}
+	return effective(model, filepath.Dir(path), resolver)
+}
+
+func effective(model Model, dir string, resolver Resolver) (Model, error) {
+	result, err := inherited(model, dir, resolver)
+	if err != nil {
+		return Model{}, err
+	}
+	result, err = importBOMs(result, resolver)
+	if err != nil {
+		return Model{}, err
+	}
+	return injectPluginManagement(result), nil
+}
+
+// inherited merges model with all of its parents, without importing any BOMs
+func inherited(model Model, dir string, resolver Resolver) (Model, error) {
+	lineage, err := parents(model, dir, resolver)
+	if err != nil {
+		return Model{}, err
+	}
+	// Start from the top and work our way down to model
+	result := deepCopy(lineage[len(lineage)-1]).(Model)
+	for i := len(lineage) - 2; i >= 0; i-- {
+		result = inherit(lineage[i], result)
+	}
+	return result, nil
+}
+
+// parents returns model followed by each of its ancestors, closest first
+func parents(model Model, dir string, resolver Resolver) ([]Model, error) {
+	lineage := []Model{model}
+	groupID, _ := model.GetGroupID()
+	artifactID, _ := model.GetArtifactID()
+	seen := map[string]bool{groupID + ":" + artifactID: true}
+	for current := model; current.Parent != nil; {
+		parent, parentDir, err := findParent(*current.Parent, dir, resolver)
+		if err != nil {
+			return nil, err
+		}
+		groupID, artifactID, _ := parentCoordinates(*current.Parent)
+		key := groupID + ":" + artifactID
+		if seen[key] {
+			return nil, fmt.Errorf("pom: parent %s refers back to itself", key)
+		}
+		seen[key] = true
+		lineage = append(lineage, parent)
+		current, dir = parent, parentDir
+	}
+	return lineage, nil
+}
+
+// findParent locates the model for parent, first through its relativePath
+// from dir, and then through resolver
+func findParent(parent Parent, dir string, resolver Resolver) (Model, string, error) {
+	groupID, artifactID, version := parentCoordinates(parent)
+	if dir != "" {
+		relativePath := "../pom.xml"
+		if parent.RelativePath != nil {
+			relativePath = *parent.RelativePath
+		}
+		// An empty <relativePath/> means the parent should not be looked for on disk
+		if relativePath != "" {
+			path := filepath.Join(dir, filepath.FromSlash(relativePath))
+			if info, err := os.Stat(path); err == nil && info.IsDir() {
+				path = filepath.Join(path, "pom.xml")
+			}
+			if candidate, err := ReadFile(path); err == nil && isParent(candidate, groupID, artifactID, version) {
+				return candidate, filepath.Dir(path), nil
+			}
+		}
+	}
+	if resolver == nil {
+		return Model{}, "", &NotFoundError{GroupID: groupID, ArtifactID: artifactID, Version: version}
+	}
+	model, err := resolver.Resolve(groupID, artifactID, version)
+	return model, "", err
+}
+
+// isParent checks that a POM found on disk is actually the one a child refers to
+func isParent(candidate Model, groupID, artifactID, version string) bool {
+	candidateGroupID, _ := candidate.GetGroupID()
+	candidateVersion, _ := candidate.GetVersion()
+	if candidate.Parent != nil {
+		// These two are inherited, so they may only be set on the candidate's own parent
+		parentGroupID, _, parentVersion := parentCoordinates(*candidate.Parent)
+		if candidateGroupID == "" {
+			candidateGroupID = parentGroupID
+		}
+		if candidateVersion == "" {
+			candidateVersion = parentVersion
+		}
+	}
+	candidateArtifactID, _ := candidate.GetArtifactID()
+	return candidateGroupID == groupID && candidateArtifactID == artifactID && candidateVersion == version
+}
+
+func parentCoordinates(parent Parent) (groupID, artifactID, version string) {
+	groupID, _ = parent.GetGroupID()
+	artifactID, _ = parent.GetArtifactID()
+	version, _ = parent.GetVersion()
+	return groupID, artifactID, version
+}
+
+// inherit returns a copy of child with everything it inherits from parent merged in.
+// parent should already be an effective model.
+func inherit(child, parent Model) Model {
+	c := deepCopy(child).(Model)
+	p := deepCopy(parent).(Model)
+	artifactID, _ := c.GetArtifactID()
+
+	// artifactId, packaging, name, modules and prerequisites are never inherited
+	c.GroupID = firstString(c.GroupID, p.GroupID)
+	c.Version = firstString(c.Version, p.Version)
+	c.ModelVersion = firstString(c.ModelVersion, p.ModelVersion)
+	c.Description = firstString(c.Description, p.Description)
+	c.InceptionYear = firstString(c.InceptionYear, p.InceptionYear)
+	c.ChildProjectURLInheritAppendPath = firstString(c.ChildProjectURLInheritAppendPath, p.ChildProjectURLInheritAppendPath)
+	if c.URL == nil && p.URL != nil {
+		c.URL = inheritedURL(*p.URL, artifactID, p.ChildProjectURLInheritAppendPath)
+	}
+	if c.Organization == nil {
+		c.Organization = p.Organization
+	}
+	if c.Licenses == nil || len(c.Licenses.License) == 0 {
+		c.Licenses = p.Licenses
+	}
+	if c.Developers == nil || len(c.Developers.Developer) == 0 {
+		c.Developers = p.Developers
+	}
+	if c.Contributors == nil || len(c.Contributors.Contributor) == 0 {
+		c.Contributors = p.Contributors
+	}
+	if c.MailingLists == nil || len(c.MailingLists.MailingList) == 0 {
+		c.MailingLists = p.MailingLists
+	}
+	if c.IssueManagement == nil {
+		c.IssueManagement = p.IssueManagement
+	}
+	if c.CiManagement == nil {
+		c.CiManagement = p.CiManagement
+	}
+	c.Scm = inheritScm(c.Scm, p.Scm, artifactID)
+	c.DistributionManagement = inheritDistributionManagement(c.DistributionManagement, p.DistributionManagement, artifactID)
+	c.Properties = mergeProperties(c.Properties, p.Properties)
+	c.DependencyManagement = mergeDependencyManagement(c.DependencyManagement, p.DependencyManagement)
+	c.Dependencies = mergeDependencies(c.Dependencies, p.Dependencies)
+	c.Repositories = mergeRepositories(c.Repositories, p.Repositories)
+	c.PluginRepositories = mergePluginRepositories(c.PluginRepositories, p.PluginRepositories)
+	c.Build = mergeBuild(c.Build, p.Build)
+	c.Reporting = mergeReporting(c.Reporting, p.Reporting)
+	c.Profiles = mergeProfiles(c.Profiles, p.Profiles)
+	return c
+}
+
+// firstString returns the first value that is set
+func firstString(values ...*string) *string {
+	for _, value := range values {
+		if value != nil {
+			return value
+		}
+	}
+	return nil
+}
+
+// inheritedURL adds the child's artifactId to the end of a parent's URL,
+// unless the parent has turned that off with child.*.inherit.append.path="false"
+func inheritedURL(parentURL, artifactID string, appendPath *string) *string {
+	if appendPath != nil && strings.TrimSpace(*appendPath) == "false" {
+		return &parentURL
+	}
+	url := strings.TrimSuffix(parentURL, "/") + "/" + artifactID
+	if strings.HasSuffix(parentURL, "/") {
+		url += "/"
+	}
+	return &url
+}
+
+func inheritScm(child, parent *Scm, artifactID string) *Scm {
+	if parent == nil {
+		return child
+	}
+	if child == nil {
+		child = &Scm{}
+	}
+	if child.Connection == nil && parent.Connection != nil {
+		child.Connection = inheritedURL(*parent.Connection, artifactID, parent.ChildScmConnectionInheritAppendPath)
+	}
+	if child.DeveloperConnection == nil && parent.DeveloperConnection != nil {
+		child.DeveloperConnection = inheritedURL(*parent.DeveloperConnection, artifactID, parent.ChildScmDeveloperConnectionInheritAppendPath)
+	}
+	if child.URL == nil && parent.URL != nil {
+		child.URL = inheritedURL(*parent.URL, artifactID, parent.ChildScmURLInheritAppendPath)
+	}
+	child.Tag = firstString(child.Tag, parent.Tag)
+	child.ChildScmConnectionInheritAppendPath = firstString(child.ChildScmConnectionInheritAppendPath, parent.ChildScmConnectionInheritAppendPath)
+	child.ChildScmDeveloperConnectionInheritAppendPath = firstString(child.ChildScmDeveloperConnectionInheritAppendPath, parent.ChildScmDeveloperConnectionInheritAppendPath)
+	child.ChildScmURLInheritAppendPath = firstString(child.ChildScmURLInheritAppendPath, parent.ChildScmURLInheritAppendPath)
+	return child
+}
+
+func inheritDistributionManagement(child, parent *DistributionManagement, artifactID string) *DistributionManagement {
+	if parent == nil {
+		return child
+	}
+	if child == nil {
+		child = &DistributionManagement{}
+	}
+	// relocation and status belong to the project they are written in
+	if child.Repository == nil {
+		child.Repository = parent.Repository
+	}
+	if child.SnapshotRepository == nil {
+		child.SnapshotRepository = parent.SnapshotRepository
+	}
+	child.DownloadURL = firstString(child.DownloadURL, parent.DownloadURL)
+	if parent.Site != nil {
+		if child.Site == nil {
+			child.Site = &Site{}
+		}
+		child.Site.ID = firstString(child.Site.ID, parent.Site.ID)
+		child.Site.Name = firstString(child.Site.Name, parent.Site.Name)
+		if child.Site.URL == nil && parent.Site.URL != nil {
+			child.Site.URL = inheritedURL(*parent.Site.URL, artifactID, parent.Site.ChildSiteURLInheritAppendPath)
+		}
+		child.Site.ChildSiteURLInheritAppendPath = firstString(child.Site.ChildSiteURLInheritAppendPath, parent.Site.ChildSiteURLInheritAppendPath)
+	}
+	return child
+}
+
+// mergeProperties returns the parent's properties with the child's added on
+// top.  Properties keep the parent's order, with anything new to the child at the end.
+func mergeProperties(child, parent *XMLProperties) *XMLProperties {
+	if parent == nil || len(parent.Elements) == 0 {
+		return child
+	}
+	if child == nil {
+		return parent
+	}
+	result := &XMLProperties{Comment: child.Comment}
+	index := make(map[string]int)
+	for _, entry := range parent.Elements {
+		index[entry.XMLName.Local] = len(result.Elements)
+		result.Elements = append(result.Elements, entry)
+	}
+	for _, entry := range child.Elements {
+		if i, ok := index[entry.XMLName.Local]; ok {
+			result.Elements[i] = entry
+		} else {
+			index[entry.XMLName.Local] = len(result.Elements)
+			result.Elements = append(result.Elements, entry)
+		}
+	}
+	return result
+}
+
+// dependencyKey identifies a dependency for merging: groupId:artifactId:type:classifier
+func dependencyKey(d *Dependency) string {
+	groupID, _ := d.GetGroupID()
+	artifactID, _ := d.GetArtifactID()
+	dependencyType, ok := d.GetType()
+	if !ok || dependencyType == "" {
+		dependencyType = "jar"
+	}
+	classifier, _ := d.GetClassifier()
+	return groupID + ":" + artifactID + ":" + dependencyType + ":" + classifier
+}
+
+// mergeDependencies keeps all of the child's dependencies, and adds any of
+// the parent's that the child does not declare itself
+func mergeDependencies(child, parent *SequenceDependency) *SequenceDependency {
+	if parent == nil || len(parent.Dependency) == 0 {
+		return child
+	}
+	if child == nil {
+		return parent
+	}
+	declared := make(map[string]bool)
+	for _, d := range child.Dependency {
+		declared[dependencyKey(d)] = true
+	}
+	for _, d := range parent.Dependency {
+		if !declared[dependencyKey(d)] {
+			child.Dependency = append(child.Dependency, d)
+		}
+	}
+	return child
+}
+
+func mergeDependencyManagement(child, parent *DependencyManagement) *DependencyManagement {
+	if parent == nil {
+		return child
+	}
+	if child == nil {
+		return parent
+	}
+	child.Dependencies = mergeDependencies(child.Dependencies, parent.Dependencies)
+	return child
+}
+
+func mergeRepositories(child, parent *SequenceRepository) *SequenceRepository {
+	if parent == nil || len(parent.Repository) == 0 {
+		return child
+	}
+	if child == nil {
+		return parent
+	}
+	child.Repository = mergeRepositoryList(child.Repository, parent.Repository)
+	return child
+}
+
+func mergePluginRepositories(child, parent *SequencePluginRepository) *SequencePluginRepository {
+	if parent == nil || len(parent.PluginRepository) == 0 {
+		return child
+	}
+	if child == nil {
+		return parent
+	}
+	child.PluginRepository = mergeRepositoryList(child.PluginRepository, parent.PluginRepository)
+	return child
+}
+
+// mergeRepositoryList adds the parent's repositories to the child's, matching them up by id
+func mergeRepositoryList(child, parent []*Repository) []*Repository {
+	declared := make(map[string]bool)
+	for _, r := range child {
+		id, _ := r.GetID()
+		declared[id] = true
+	}
+	for _, r := range parent {
+		if id, _ := r.GetID(); !declared[id] {
+			child = append(child, r)
+		}
+	}
+	return child
+}
+
+func mergeBuild(child, parent *Build) *Build {
+	if parent == nil {
+		return child
+	}
+	if child == nil {
+		child = &Build{}
+	}
+	child.SourceDirectory = firstString(child.SourceDirectory, parent.SourceDirectory)
+	child.ScriptSourceDirectory = firstString(child.ScriptSourceDirectory, parent.ScriptSourceDirectory)
+	child.TestSourceDirectory = firstString(child.TestSourceDirectory, parent.TestSourceDirectory)
+	child.OutputDirectory = firstString(child.OutputDirectory, parent.OutputDirectory)
+	child.TestOutputDirectory = firstString(child.TestOutputDirectory, parent.TestOutputDirectory)
+	child.DefaultGoal = firstString(child.DefaultGoal, parent.DefaultGoal)
+	child.Directory = firstString(child.Directory, parent.Directory)
+	child.FinalName = firstString(child.FinalName, parent.FinalName)
+	if child.Resources == nil || len(child.Resources.Resource) == 0 {
+		child.Resources = parent.Resources
+	}
+	if child.TestResources == nil || len(child.TestResources.TestResource) == 0 {
+		child.TestResources = parent.TestResources
+	}
+	child.Filters = mergeFilters(child.Filters, parent.Filters)
+	child.Extensions = mergeExtensions(child.Extensions, parent.Extensions)
+	child.PluginManagement = mergePluginManagement(child.PluginManagement, parent.PluginManagement)
+	child.Plugins = mergePlugins(child.Plugins, parent.Plugins, true)
+	return child
+}
+
+func mergeFilters(child, parent *SequenceFilter) *SequenceFilter {
+	if parent == nil || len(parent.Filter) == 0 {
+		return child
+	}
+	if child == nil {
+		return parent
+	}
+	declared := make(map[string]bool)
+	for _, f := range child.Filter {
+		if f != nil {
+			declared[*f] = true
+		}
+	}
+	for _, f := range parent.Filter {
+		if f != nil && !declared[*f] {
+			child.Filter = append(child.Filter, f)
+		}
+	}
+	return child
+}
+
+func mergeExtensions(child, parent *SequenceExtension) *SequenceExtension {
+	if parent == nil || len(parent.Extension) == 0 {
+		return child
+	}
+	if child == nil {
+		return parent
+	}
+	declared := make(map[string]bool)
+	for _, e := range child.Extension {
+		groupID, _ := e.GetGroupID()
+		artifactID, _ := e.GetArtifactID()
+		declared[groupID+":"+artifactID] = true
+	}
+	for _, e := range parent.Extension {
+		groupID, _ := e.GetGroupID()
+		artifactID, _ := e.GetArtifactID()
+		if !declared[groupID+":"+artifactID] {
+			child.Extension = append(child.Extension, e)
+		}
+	}
+	return child
+}
+
+func mergePluginManagement(child, parent *PluginManagement) *PluginManagement {
+	if parent == nil {
+		return child
+	}
+	if child == nil {
+		child = &PluginManagement{}
+	}
+	child.Plugins = mergePlugins(child.Plugins, parent.Plugins, true)
+	if child.Plugins == nil {
+		return nil
+	}
+	return child
+}
+
+// pluginKey identifies a plugin by groupId:artifactId.
+// The groupId defaults to org.apache.maven.plugins, just like in Maven.
+func pluginKey(groupID *string, artifactID *string) string {
+	group := "org.apache.maven.plugins"
+	if groupID != nil && *groupID != "" {
+		group = *groupID
+	}
+	artifact := ""
+	if artifactID != nil {
+		artifact = *artifactID
+	}
+	return group + ":" + artifact
+}
+
+// isInherited reads an <inherited> flag, which defaults to true
+func isInherited(inherited *string) bool {
+	return inherited == nil || strings.TrimSpace(*inherited) != "false"
+}
+
+// mergePlugins merges the plugins of a child and parent, matching them by
+// groupId:artifactId.  Parent plugins come first, followed by anything only the child has.
+// When inheriting, plugins marked <inherited>false</inherited> in the parent are left out.
+func mergePlugins(child, parent *SequencePlugin, inheriting bool) *SequencePlugin {
+	if parent == nil || len(parent.Plugin) == 0 {
+		return child
+	}
+	childPlugins := make(map[string]*Plugin)
+	if child != nil {
+		for _, plugin := range child.Plugin {
+			childPlugins[pluginKey(plugin.GroupID, plugin.ArtifactID)] = plugin
+		}
+	}
+	result := &SequencePlugin{}
+	if child != nil {
+		result.Comment = child.Comment
+	}
+	used := make(map[string]bool)
+	for _, plugin := range parent.Plugin {
+		if inheriting && !isInherited(plugin.Inherited) {
+			continue
+		}
+		key := pluginKey(plugin.GroupID, plugin.ArtifactID)
+		if own, ok := childPlugins[key]; ok {
+			result.Plugin = append(result.Plugin, mergePlugin(own, plugin, inheriting))
+			used[key] = true
+		} else {
+			// Still merged, so that executions that are not inherited get left behind
+			result.Plugin = append(result.Plugin, mergePlugin(&Plugin{ArtifactID: plugin.ArtifactID}, plugin, inheriting))
+		}
+	}
+	if child != nil {
+		for _, plugin := range child.Plugin {
+			if !used[pluginKey(plugin.GroupID, plugin.ArtifactID)] {
+				result.Plugin = append(result.Plugin, plugin)
+			}
+		}
+	}
+	if len(result.Plugin) == 0 {
+		return child
+	}
+	return result
+}
+
+// mergePlugin merges a parent's declaration of a plugin into the child's.
+// Executions are matched up by id, and configurations are merged with MergeConfiguration.
+func mergePlugin(child, parent *Plugin, inheriting bool) *Plugin {
+	child.GroupID = firstString(child.GroupID, parent.GroupID)
+	child.Version = firstString(child.Version, parent.Version)
+	child.Extensions = firstString(child.Extensions, parent.Extensions)
+	child.Inherited = firstString(child.Inherited, parent.Inherited)
+	if child.Goals == nil {
+		child.Goals = parent.Goals
+	}
+	child.Configuration = mergeConfigurationQuietly(child.Configuration, parent.Configuration)
+	child.Dependencies = mergeDependencies(child.Dependencies, parent.Dependencies)
+	child.Executions = mergeExecutions(child.Executions, parent.Executions, inheriting)
+	return child
+}
+
+// executionID returns the id of an execution, which defaults to "default"
+func executionID(execution *PluginExecution) string {
+	if id, ok := execution.GetID(); ok && id != "" {
+		return id
+	}
+	return "default"
+}
+
+func mergeExecutions(child, parent *SequenceExecution, inheriting bool) *SequenceExecution {
+	if parent == nil || len(parent.Execution) == 0 {
+		return child
+	}
+	childExecutions := make(map[string]*PluginExecution)
+	if child != nil {
+		for _, execution := range child.Execution {
+			childExecutions[executionID(execution)] = execution
+		}
+	}
+	result := &SequenceExecution{}
+	if child != nil {
+		result.Comment = child.Comment
+	}
+	used := make(map[string]bool)
+	for _, execution := range parent.Execution {
+		if inheriting && !isInherited(execution.Inherited) {
+			continue
+		}
+		id := executionID(execution)
+		if own, ok := childExecutions[id]; ok {
+			own.Phase = firstString(own.Phase, execution.Phase)
+			own.Inherited = firstString(own.Inherited, execution.Inherited)
+			if own.Goals == nil {
+				own.Goals = execution.Goals
+			} else if execution.Goals != nil {
+				own.Goals.Goal = mergeGoals(execution.Goals.Goal, own.Goals.Goal)
+			}
+			own.Configuration = mergeConfigurationQuietly(own.Configuration, execution.Configuration)
+			result.Execution = append(result.Execution, own)
+			used[id] = true
+		} else {
+			result.Execution = append(result.Execution, execution)
+		}
+	}
+	if child != nil {
+		for _, execution := range child.Execution {
+			if !used[executionID(execution)] {
+				result.Execution = append(result.Execution, execution)
+			}
+		}
+	}
+	if len(result.Execution) == 0 {
+		return child
+	}
+	return result
+}
+
+// mergeGoals adds the child's goals to the parent's, without duplicates
+func mergeGoals(parent, child []*string) []*string {
+	result := make([]*string, 0, len(parent)+len(child))
+	seen := make(map[string]bool)
+	for _, goal := range append(append([]*string{}, parent...), child...) {
+		if goal != nil && !seen[*goal] {
+			seen[*goal] = true
+			result = append(result, goal)
+		}
+	}
+	return result
+}
+
+func mergeReporting(child, parent *Reporting) *Reporting {
+	if parent == nil {
+		return child
+	}
+	if child == nil {
+		child = &Reporting{}
+	}
+	child.ExcludeDefaults = firstString(child.ExcludeDefaults, parent.ExcludeDefaults)
+	child.OutputDirectory = firstString(child.OutputDirectory, parent.OutputDirectory)
+	if parent.Plugins == nil {
+		return child
+	}
+	childPlugins := make(map[string]*ReportPlugin)
+	if child.Plugins != nil {
+		for _, plugin := range child.Plugins.Plugin {
+			childPlugins[pluginKey(plugin.GroupID, plugin.ArtifactID)] = plugin
+		}
+	} else {
+		child.Plugins = &SequenceReportPlugin{}
+	}
+	for _, plugin := range parent.Plugins.Plugin {
+		if !isInherited(plugin.Inherited) {
+			continue
+		}
+		own, ok := childPlugins[pluginKey(plugin.GroupID, plugin.ArtifactID)]
+		if !ok {
+			child.Plugins.Plugin = append(child.Plugins.Plugin, plugin)
+			continue
+		}
+		own.GroupID = firstString(own.GroupID, plugin.GroupID)
+		own.Version = firstString(own.Version, plugin.Version)
+		if own.Configuration == nil {
+			own.Configuration = plugin.Configuration
+		}
+		if own.ReportSets == nil {
+			own.ReportSets = plugin.ReportSets
+		}
+	}
+	return child
+}
+
+// mergeProfiles adds any of the parent's profiles that the child does not
+// declare itself.  Profiles with the same id are not merged, the child's wins.
+func mergeProfiles(child, parent *SequenceProfile) *SequenceProfile {
+	if parent == nil || len(parent.Profile) == 0 {
+		return child
+	}
+	if child == nil {
+		return parent
+	}
+	declared := make(map[string]bool)
+	for _, profile := range child.Profile {
+		id, _ := profile.GetID()
+		declared[id] = true
+	}
+	for _, profile := range parent.Profile {
+		if id, _ := profile.GetID(); !declared[id] {
+			child.Profile = append(child.Profile, profile)
+		}
+	}
+	return child
+}
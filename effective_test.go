@@ -0,0 +1,192 @@
+package pom
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var effectiveGrandparentPom = `<project>
+    <modelVersion>4.0.0</modelVersion>
+    <groupId>com.example</groupId>
+    <artifactId>grandparent</artifactId>
+    <version>1</version>
+    <url>https://example.com/</url>
+    <properties>
+        <slf4j.version>1.7.6</slf4j.version>
+        <java.version>1.8</java.version>
+    </properties>
+</project>`
+
+var effectiveParentPom = `<project>
+    <parent>
+        <groupId>com.example</groupId>
+        <artifactId>grandparent</artifactId>
+        <version>1</version>
+    </parent>
+    <artifactId>parent</artifactId>
+    <version>2.0.0</version>
+    <packaging>pom</packaging>
+    <name>The Parent</name>
+    <scm child.scm.url.inherit.append.path="false">
+        <connection>scm:git:https://example.com/repo.git</connection>
+        <url>https://example.com/repo</url>
+    </scm>
+    <properties>
+        <java.version>11</java.version>
+    </properties>
+    <dependencyManagement>
+        <dependencies>
+            <dependency>
+                <groupId>org.slf4j</groupId>
+                <artifactId>slf4j-api</artifactId>
+                <version>${slf4j.version}</version>
+            </dependency>
+        </dependencies>
+    </dependencyManagement>
+    <dependencies>
+        <dependency>
+            <groupId>junit</groupId>
+            <artifactId>junit</artifactId>
+            <version>4.11</version>
+            <scope>test</scope>
+        </dependency>
+    </dependencies>
+    <build>
+        <plugins>
+            <plugin>
+                <artifactId>maven-compiler-plugin</artifactId>
+                <version>3.1</version>
+                <executions>
+                    <execution>
+                        <id>compile</id>
+                        <goals>
+                            <goal>compile</goal>
+                        </goals>
+                    </execution>
+                    <execution>
+                        <id>local</id>
+                        <inherited>false</inherited>
+                    </execution>
+                </executions>
+            </plugin>
+            <plugin>
+                <artifactId>maven-enforcer-plugin</artifactId>
+                <inherited>false</inherited>
+            </plugin>
+        </plugins>
+    </build>
+    <profiles>
+        <profile>
+            <id>release</id>
+        </profile>
+    </profiles>
+</project>`
+
+var effectiveChildPom = `<project>
+    <parent>
+        <groupId>com.example</groupId>
+        <artifactId>parent</artifactId>
+        <version>2.0.0</version>
+    </parent>
+    <artifactId>child</artifactId>
+    <properties>
+        <slf4j.version>1.7.25</slf4j.version>
+    </properties>
+    <build>
+        <plugins>
+            <plugin>
+                <artifactId>maven-compiler-plugin</artifactId>
+                <executions>
+                    <execution>
+                        <id>compile</id>
+                        <phase>process-sources</phase>
+                        <goals>
+                            <goal>testCompile</goal>
+                        </goals>
+                    </execution>
+                </executions>
+            </plugin>
+        </plugins>
+    </build>
+</project>`
+
+// mapResolver resolves POMs from memory, keyed by groupId:artifactId:version
+type mapResolver map[string]string
+
+func (m mapResolver) Resolve(groupID, artifactID, version string) (Model, error) {
+	raw, ok := m[groupID+":"+artifactID+":"+version]
+	if !ok {
+		return Model{}, &NotFoundError{GroupID: groupID, ArtifactID: artifactID, Version: version}
+	}
+	return Unmarshal([]byte(raw))
+}
+
+func TestEffectiveFile(t *testing.T) {
+	a := assert.New(t)
+	dir, err := ioutil.TempDir("", "pom")
+	a.NoError(err)
+	defer os.RemoveAll(dir)
+	a.NoError(ioutil.WriteFile(filepath.Join(dir, "pom.xml"), []byte(effectiveParentPom), 0644))
+	a.NoError(os.Mkdir(filepath.Join(dir, "child"), 0755))
+	childPath := filepath.Join(dir, "child", "pom.xml")
+	a.NoError(ioutil.WriteFile(childPath, []byte(effectiveChildPom), 0644))
+
+	// The parent is on disk, but the grandparent has to come from the resolver
+	resolver := mapResolver{"com.example:grandparent:1": effectiveGrandparentPom}
+	model, err := EffectiveFile(childPath, resolver)
+	a.NoError(err, "Error computing effective model")
+
+	groupID, _ := model.GetGroupID()
+	a.Equal("com.example", groupID, "groupId was not inherited")
+	version, _ := model.GetVersion()
+	a.Equal("2.0.0", version, "version was not inherited")
+	_, ok := model.GetName()
+	a.False(ok, "name should not be inherited")
+	_, ok = model.GetPackaging()
+	a.False(ok, "packaging should not be inherited")
+	url, _ := model.GetURL()
+	a.Equal("https://example.com/parent/child/", url, "url did not have the artifactIds appended")
+	a.Equal("scm:git:https://example.com/repo.git/child", *model.Scm.Connection)
+	a.Equal("https://example.com/repo", *model.Scm.URL, "scm url should not be appended to")
+
+	properties := map[string]string{}
+	for _, entry := range model.Properties.Elements {
+		properties[entry.XMLName.Local] = entry.Value
+	}
+	a.Equal(map[string]string{"slf4j.version": "1.7.25", "java.version": "11"}, properties)
+
+	a.Equal(1, len(model.DependencyManagement.Dependencies.Dependency), "dependencyManagement was not inherited")
+	a.Equal(1, len(model.Dependencies.Dependency), "dependencies were not inherited")
+
+	plugins := model.Build.Plugins.Plugin
+	a.Equal(1, len(plugins), "Only the compiler plugin should be inherited")
+	a.Equal("3.1", *plugins[0].Version, "plugin version was not inherited")
+	executions := plugins[0].Executions.Execution
+	a.Equal(1, len(executions), "Executions were not merged by id")
+	a.Equal("process-sources", *executions[0].Phase)
+	a.Equal(2, len(executions[0].Goals.Goal), "Goals were not merged")
+
+	a.Equal(1, len(model.Profiles.Profile), "Profiles were not inherited")
+}
+
+func TestEffectiveResolverOnly(t *testing.T) {
+	a := assert.New(t)
+	resolver := mapResolver{
+		"com.example:grandparent:1": effectiveGrandparentPom,
+		"com.example:parent:2.0.0":  effectiveParentPom,
+	}
+	child, err := Unmarshal([]byte(effectiveChildPom))
+	a.NoError(err, "Error unmarshalling test data")
+	model, err := Effective(child, resolver)
+	a.NoError(err, "Error computing effective model")
+	modelVersion, _ := model.GetModelVersion()
+	a.Equal("4.0.0", modelVersion)
+	a.Nil(child.GroupID, "The child model was changed")
+
+	_, err = Effective(child, mapResolver{})
+	a.IsType(&NotFoundError{}, err, "A missing parent should be reported")
+}
@@ -0,0 +1,324 @@
+package pom
+
+import (
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Location is where in a POM a problem was found.  Lines and columns start at 1,
+// and a column of 0 means it is not known.
+// Path is the element the problem is in, in the form Positions.Lookup takes,
+// like project/dependencies/dependency[3]/optional.
+type Location struct {
+	File         string
+	Line, Column int
+	Path         string
+}
+
+func (l Location) String() string {
+	s := strconv.Itoa(l.Line)
+	if l.Column > 0 {
+		s += ":" + strconv.Itoa(l.Column)
+	}
+	if l.File != "" {
+		s = l.File + ":" + s
+	}
+	if l.Path != "" {
+		s += ": " + l.Path
+	}
+	return s
+}
+
+// SyntaxError is a POM that is not well formed XML
+type SyntaxError struct {
+	Location
+	Msg string
+}
+
+func (e *SyntaxError) Error() string {
+	return "pom: " + e.Location.String() + ": " + e.Msg
+}
+
+// UnknownElementError is an element that has no place in the model where it was written,
+// like a misspelt name or a <dependency> directly under <project>
+type UnknownElementError struct {
+	Location
+	Element string
+}
+
+func (e *UnknownElementError) Error() string {
+	return "pom: " + e.Location.String() + ": unknown element <" + e.Element + ">"
+}
+
+// DuplicateElementError is an element written more than once where the model only has room for one.
+// Unmarshal keeps the last one.
+type DuplicateElementError struct {
+	Location
+	Element string
+}
+
+func (e *DuplicateElementError) Error() string {
+	return "pom: " + e.Location.String() + ": duplicated element <" + e.Element + ">"
+}
+
+// UnexpectedTextError is text written inside of an element that only holds other elements
+type UnexpectedTextError struct {
+	Location
+	Text string
+}
+
+func (e *UnexpectedTextError) Error() string {
+	return fmt.Sprintf("pom: %s: unexpected text %q", e.Location, e.Text)
+}
+
+// TypeError is a value that can not be read as the type its element needs, like
+// <optional>yes</optional>
+type TypeError struct {
+	Location
+	Value string
+	Type  string
+}
+
+func (e *TypeError) Error() string {
+	return fmt.Sprintf("pom: %s: %q is not a valid %s", e.Location, e.Value, e.Type)
+}
+
+// Errors is every problem found in a POM, in the order they were written
+type Errors []error
+
+func (e Errors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "\n")
+}
+
+// booleanElements hold booleans in Maven, but are kept as strings in the model
+// so they can be written back exactly as they were
+var booleanElements = map[string]bool{
+	"optional":   true,
+	"inherited":  true,
+	"extensions": true,
+	"filtering":  true,
+	"enabled":    true,
+}
+
+// Check reads rawPom and reports every problem with it in one pass, as Errors.
+// That is everything UnmarshalStrict fails on, as well as values of the wrong type.
+// file is only used to label the problems, and can be left empty.
+// A SyntaxError stops the check, as nothing after it can be read.
+// Usage:
+//   if err := pom.Check("pom.xml", raw); err != nil {
+//       for _, problem := range err.(pom.Errors) {
+//           switch problem.(type) {
+//           case *pom.UnknownElementError:
+//               ...
+//           }
+//       }
+//   }
+func Check(file string, rawPom []byte) error {
+	data, _, err := toUTF8(rawPom)
+	if err != nil {
+		return err
+	}
+	c := &checker{file: file, raw: data}
+	c.run()
+	if len(c.errs) == 0 {
+		return nil
+	}
+	return c.errs
+}
+
+// locateError turns an error from encoding/xml into one of our own, saying where it happened
+func locateError(file string, rawPom []byte, err error) error {
+	c := &checker{file: file, raw: rawPom, decodeOnly: true}
+	c.run()
+	if len(c.errs) > 0 {
+		return c.errs[0]
+	}
+	if syntaxErr, ok := err.(*xml.SyntaxError); ok {
+		return &SyntaxError{Location: Location{File: file, Line: syntaxErr.Line}, Msg: syntaxErr.Msg}
+	}
+	return err
+}
+
+// StrictOptions change how UnmarshalStrict deals with problems
+type StrictOptions struct {
+	// File labels the problems found, and can be left empty
+	File string
+	// Warn is called with each problem instead of failing, if it is set
+	Warn func(error)
+}
+
+// UnmarshalStrict is Unmarshal that also fails on anything encoding/xml would quietly
+// skip over, the same as Maven's strict reader.  That is unknown elements, elements
+// written twice where only one is allowed, and text where there should only be elements.
+// The error is then Errors, listing every problem found.
+// Usage:
+//   model, err := pom.UnmarshalStrict(raw, pom.StrictOptions{File: "pom.xml", Warn: func(err error) {
+//       log.Println(err)
+//   }})
+func UnmarshalStrict(rawPom []byte, options ...StrictOptions) (Model, error) {
+	var opts StrictOptions
+	if len(options) > 0 {
+		opts = options[0]
+	}
+	data, charset, err := toUTF8(rawPom)
+	if err != nil {
+		return Model{}, err
+	}
+	c := &checker{file: opts.File, raw: data}
+	c.run()
+	for _, err := range c.errs {
+		if _, ok := err.(*SyntaxError); ok {
+			return Model{}, c.errs
+		}
+	}
+	if len(c.errs) > 0 {
+		if opts.Warn == nil {
+			return Model{}, c.errs
+		}
+		for _, err := range c.errs {
+			opts.Warn(err)
+		}
+	}
+	model, err := unmarshalUTF8(opts.File, data)
+	if err != nil {
+		return Model{}, err
+	}
+	model.Encoding = charset
+	return model, nil
+}
+
+// checker walks a parsed POM alongside the types of the model it is read into
+type checker struct {
+	file string
+	raw  []byte
+	// decodeOnly only reports the problems encoding/xml gives up on
+	decodeOnly bool
+	lines      []int
+	errs       Errors
+}
+
+func (c *checker) run() {
+	c.lines = lineOffsets(c.raw)
+	tree, err := parseTree(c.raw)
+	if err != nil {
+		if syntaxErr, ok := err.(*SyntaxError); ok {
+			syntaxErr.File = c.file
+		}
+		c.errs = append(c.errs, err)
+		return
+	}
+	root := firstElement(tree)
+	if root == nil {
+		c.errs = append(c.errs, &SyntaxError{Location: Location{File: c.file, Line: 1, Column: 1}, Msg: "document has no project element"})
+		return
+	}
+	if root.name.Local != "project" && !c.decodeOnly {
+		c.errs = append(c.errs, &UnknownElementError{Location: c.location(root), Element: root.name.Local})
+		return
+	}
+	c.check(root, reflect.TypeOf(Model{}))
+}
+
+func (c *checker) location(n *node) Location {
+	line, column := lineColumn(c.lines, n.start)
+	return Location{File: c.file, Line: line, Column: column, Path: n.path()}
+}
+
+// check looks at everything inside of n, which is read into the struct type t
+func (c *checker) check(n *node, t reflect.Type) {
+	fields, any, text := xmlFields(t)
+	seen := make(map[string]bool)
+	for _, e := range n.children {
+		if e.kind == textNode && !text && !c.decodeOnly && strings.TrimSpace(e.text) != "" {
+			c.errs = append(c.errs, c.textError(n, e))
+		}
+		if e.kind != elementNode {
+			continue
+		}
+		field, ok := fields[e.name.Local]
+		if !ok {
+			if !any && !c.decodeOnly {
+				c.errs = append(c.errs, &UnknownElementError{Location: c.location(e), Element: e.name.Local})
+			}
+			continue
+		}
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Slice {
+			fieldType = fieldType.Elem()
+		} else if seen[e.name.Local] && !c.decodeOnly {
+			c.errs = append(c.errs, &DuplicateElementError{Location: c.location(e), Element: e.name.Local})
+		}
+		seen[e.name.Local] = true
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		value := strings.TrimSpace(e.textContent())
+		if fieldType.Kind() != reflect.Struct && e.hasElements() && !c.decodeOnly {
+			// A value can only be text
+			inner := firstElement(e)
+			c.errs = append(c.errs, &UnknownElementError{Location: c.location(inner), Element: inner.name.Local})
+			continue
+		}
+		switch fieldType.Kind() {
+		case reflect.Bool:
+			if _, err := strconv.ParseBool(value); err != nil {
+				c.errs = append(c.errs, &TypeError{Location: c.location(e), Value: value, Type: "boolean"})
+			}
+		case reflect.String:
+			if booleanElements[e.name.Local] && !c.decodeOnly && !strings.Contains(value, "${") &&
+				!strings.EqualFold(value, "true") && !strings.EqualFold(value, "false") {
+				c.errs = append(c.errs, &TypeError{Location: c.location(e), Value: value, Type: "boolean"})
+			}
+		case reflect.Struct:
+			// Types that read themselves take whatever is inside of them
+			if !reflect.PtrTo(fieldType).Implements(reflect.TypeOf((*xml.Unmarshaler)(nil)).Elem()) {
+				c.check(e, fieldType)
+			}
+		}
+	}
+}
+
+// textError reports the text node e written inside of the element n
+func (c *checker) textError(n, e *node) error {
+	text := strings.TrimSpace(e.text)
+	offset := e.start + strings.Index(e.text, text)
+	line, column := lineColumn(c.lines, offset)
+	return &UnexpectedTextError{Location: Location{File: c.file, Line: line, Column: column, Path: n.path()}, Text: text}
+}
+
+// xmlFields returns the fields of t by the element name they are read from, whether
+// t also takes elements with any other name, and whether it takes text
+func xmlFields(t reflect.Type) (map[string]reflect.StructField, bool, bool) {
+	fields := make(map[string]reflect.StructField)
+	any, text := false, false
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		parts := strings.Split(field.Tag.Get("xml"), ",")
+		if parts[0] != "" && parts[0] != "-" {
+			fields[parts[0]] = field
+			continue
+		}
+		flags := "," + strings.Join(parts[1:], ",") + ","
+		if strings.Contains(flags, ",innerxml,") || (strings.Contains(flags, ",any,") && !strings.Contains(flags, ",attr,")) {
+			any = true
+		}
+		if strings.Contains(flags, ",innerxml,") || strings.Contains(flags, ",chardata,") {
+			text = true
+		}
+	}
+	return fields, any, text
+}
+
+// lineColumn returns the line and column of offset, given the offsets each line starts at
+func lineColumn(lines []int, offset int) (int, int) {
+	line := sort.Search(len(lines), func(i int) bool { return lines[i] > offset })
+	return line, offset - lines[line-1] + 1
+}
@@ -0,0 +1,127 @@
+package pom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnmarshalSyntaxError(t *testing.T) {
+	a := assert.New(t)
+	_, err := Unmarshal([]byte("<project>\n  <dependencies>\n    <dependency>\n  </dependencies>\n</project>"))
+	a.EqualError(err, "pom: 4:3: project/dependencies/dependency: unexpected end element </dependencies>")
+	syntaxErr, ok := err.(*SyntaxError)
+	a.True(ok)
+	a.Equal(Location{Line: 4, Column: 3, Path: "project/dependencies/dependency"}, syntaxErr.Location)
+
+	_, err = Unmarshal([]byte("<project>\n  <name>Tom & Jerry</name>\n</project>"))
+	a.IsType(&SyntaxError{}, err)
+	a.Equal(2, err.(*SyntaxError).Line)
+	a.Equal("project/name", err.(*SyntaxError).Path)
+
+	_, err = UnmarshalDocument([]byte("<project>\n  <modules>\n    <module>a</module>\n    <module>b</modules>\n</project>"))
+	a.EqualError(err, "pom: 4:14: project/modules/module[1]: unexpected end element </modules>")
+}
+
+func TestUnmarshalTypeError(t *testing.T) {
+	a := assert.New(t)
+	_, err := Unmarshal([]byte(`<project>
+    <profiles>
+        <profile><id>a</id></profile>
+        <profile>
+            <id>b</id>
+            <activation><activeByDefault>yes</activeByDefault></activation>
+        </profile>
+    </profiles>
+</project>`))
+	a.EqualError(err, `pom: 6:25: project/profiles/profile[1]/activation/activeByDefault: "yes" is not a valid boolean`)
+	a.IsType(&TypeError{}, err)
+}
+
+func TestCheck(t *testing.T) {
+	a := assert.New(t)
+	a.NoError(Check("pom.xml", []byte(examplePom)), "anything goes inside of plugin configuration")
+
+	err := Check("pom.xml", []byte(`<project>
+    <artifactId>check</artifactId>
+    <dependency>
+        <artifactId>misplaced</artifactId>
+    </dependency>
+    <dependencies>
+        <dependency>
+            <artifactId>a</artifactId>
+            <optional>yes</optional>
+        </dependency>
+        <dependency>
+            <artifactId>b</artifactId>
+            <optional>${optional}</optional>
+            <verison>1</verison>
+        </dependency>
+    </dependencies>
+    <properties>
+        <anything.goes>true</anything.goes>
+    </properties>
+</project>`))
+	errs, ok := err.(Errors)
+	a.True(ok)
+	a.Len(errs, 3)
+	a.IsType(&UnknownElementError{}, errs[0])
+	a.Equal("dependency", errs[0].(*UnknownElementError).Element)
+	a.IsType(&TypeError{}, errs[1])
+	a.Equal(Location{File: "pom.xml", Line: 9, Column: 13, Path: "project/dependencies/dependency[0]/optional"}, errs[1].(*TypeError).Location)
+	a.EqualError(errs[2], "pom: pom.xml:14:13: project/dependencies/dependency[1]/verison: unknown element <verison>")
+	a.Equal("pom: pom.xml:3:5: project/dependency: unknown element <dependency>\n"+
+		`pom: pom.xml:9:13: project/dependencies/dependency[0]/optional: "yes" is not a valid boolean`+"\n"+
+		"pom: pom.xml:14:13: project/dependencies/dependency[1]/verison: unknown element <verison>", err.Error())
+
+	err = Check("", []byte("<project>\n  <name>\n</project>"))
+	a.EqualError(err, "pom: 3:1: project/name: unexpected end element </project>")
+	err = Check("", []byte("<pom/>"))
+	a.EqualError(err, "pom: 1:1: pom: unknown element <pom>")
+}
+
+var sloppyPom = `<project>
+    <artifactId>sloppy</artifactId>
+    <version>1</version>
+    <version>2</version>
+    <build>
+        <plugins>
+            oops
+            <plugin>
+                <artifactId>maven-jar-plugin</artifactId>
+                <configuration><anything>goes</anything></configuration>
+            </plugin>
+        </plugins>
+    </build>
+    <name>sloppy <b>app</b></name>
+</project>`
+
+func TestUnmarshalStrict(t *testing.T) {
+	a := assert.New(t)
+	model, err := Unmarshal([]byte(sloppyPom))
+	a.NoError(err)
+	a.Equal("2", *model.Version, "the last one wins")
+
+	model, err = UnmarshalStrict([]byte(sloppyPom), StrictOptions{File: "pom.xml"})
+	a.Nil(model.Version)
+	a.Equal("pom: pom.xml:4:5: project/version[1]: duplicated element <version>\n"+
+		`pom: pom.xml:7:13: project/build/plugins: unexpected text "oops"`+"\n"+
+		"pom: pom.xml:14:18: project/name/b: unknown element <b>", err.Error())
+	a.IsType(&DuplicateElementError{}, err.(Errors)[0])
+	a.IsType(&UnexpectedTextError{}, err.(Errors)[1])
+	a.IsType(&UnknownElementError{}, err.(Errors)[2])
+
+	warnings := make([]error, 0)
+	model, err = UnmarshalStrict([]byte(sloppyPom), StrictOptions{Warn: func(err error) {
+		warnings = append(warnings, err)
+	}})
+	a.NoError(err)
+	a.Len(warnings, 3)
+	a.Equal("2", *model.Version)
+
+	model, err = UnmarshalStrict([]byte(examplePom))
+	a.NoError(err)
+	a.NotNil(model.Build)
+	_, err = UnmarshalStrict([]byte("<project><name>a</project>"), StrictOptions{Warn: func(error) {}})
+	a.IsType(Errors{}, err, "syntax errors always fail")
+}
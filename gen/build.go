@@ -8,6 +8,12 @@ import (
 
 var existingTypes = make(map[string]bool, 0)
 
+// sequenceNames overrides the name of a sequence type, keyed by the type of its elements.
+// <reporting> and <build> both have a <plugins> list, but they hold different types of plugin
+var sequenceNames = map[string]string{
+	"ReportPlugin": "SequenceReportPlugin",
+}
+
 // GetTypes returns the formatted struct definitions of each type
 func (s Schema) GetTypes() []string {
 	result := make([]string, 0)
@@ -55,13 +61,7 @@ func (s Schema) GetTypeAsString(target ComplexType) string {
 	myType.Fields = make([]pomTypeField, 0)
 	for _, elem := range target.All.Element {
 		abc := pomTypeField{}
-		// Time to clean up the field name
-		field := strings.Title(elem.Name)
-		// GoLint spec
-		field = strings.Replace(field, "Url", "URL", -1)
-		// GoLint spec
-		field = strings.Replace(field, "Id", "ID", -1)
-		abc.Name = field
+		abc.Name = fieldName(elem.Name)
 		// Sequence is set if the this type is a list of elements
 		seqType := elem.ComplexType.Sequence.Element.Type
 		seqName := elem.ComplexType.Sequence.Element.Name
@@ -72,6 +72,9 @@ func (s Schema) GetTypeAsString(target ComplexType) string {
 			// </models>
 			// For the <model> tag to work, we need to create a subelement struct
 			subTypeName := fmt.Sprintf("Sequence%s", strings.Title(seqName))
+			if name, ok := sequenceNames[seqType]; ok {
+				subTypeName = name
+			}
 			if ok := existingTypes[subTypeName]; !ok {
 				subTypeType := strings.Replace(strings.Replace(seqType, "xs:", "", -1), "boolean", "bool", -1)
 				subTypeDefault := fmt.Sprintf("%s{}", subTypeType)
@@ -150,6 +153,26 @@ func (s Schema) GetTypeAsString(target ComplexType) string {
 		myType.Fields = append(myType.Fields, abc)
 	}
 
+	// Attributes become fields as well, otherwise things like
+	// child.project.url.inherit.append.path are dropped when we marshal
+	for _, attr := range target.Attribute {
+		abc := pomTypeField{}
+		abc.Name = fieldName(attr.Name)
+		abc.IsPointer = true
+		abc.Type = strings.Replace(strings.Replace(attr.Type, "xs:", "", -1), "boolean", "bool", -1)
+		abc.DefaultValue = fmt.Sprintf("%s{}", abc.Type)
+		if abc.Type == "bool" {
+			abc.DefaultValue = "false"
+		} else if abc.Type == "string" {
+			abc.DefaultValue = `""`
+		}
+		abc.Tag = fmt.Sprintf(" `xml:\"%s,attr,omitempty\"`", attr.Name)
+		if len(attr.Annotation.Documentation) > 1 {
+			abc.Doc = fmt.Sprintf("\n/* %s %s*/ ", strings.Title(attr.Name), strings.TrimSpace(attr.Annotation.Documentation[1].Text))
+		}
+		myType.Fields = append(myType.Fields, abc)
+	}
+
 	// Add a comment field to the bottom of each subtype.  This way we keep comments
 	myType.Fields = append(myType.Fields, pomTypeField{
 		Name:      "Comment",
@@ -158,6 +181,16 @@ func (s Schema) GetTypeAsString(target ComplexType) string {
 		IsSlice:   false,
 		Tag:       "`xml:\",comment\"`",
 	})
+	// The project remembers the charset it was read in, so it can be written back out the same way.
+	// It is not part of the XML, so encoding/xml leaves it alone
+	if typeName == "Model" {
+		myType.Fields = append(myType.Fields, pomTypeField{
+			Name: "Encoding",
+			Doc:  "\n/* Encoding The charset the POM was read in, like ISO-8859-1.  Marshal writes it back out\n   in the same charset.  Empty means UTF-8.*/ ",
+			Type: "string",
+			Tag:  "`xml:\"-\"`",
+		})
+	}
 	types = append(types, myType)
 
 	// Parsing template out to a buffer
@@ -167,3 +200,15 @@ func (s Schema) GetTypeAsString(target ComplexType) string {
 	// Return the string representation of this struct definition
 	return buff.String()
 }
+
+// fieldName turns the name of an XML element or attribute into a Go field name
+func fieldName(name string) string {
+	// Time to clean up the field name.  Attributes can have dots in them,
+	// which strings.Title treats as the start of a new word
+	field := strings.Replace(strings.Title(name), ".", "", -1)
+	// GoLint spec
+	field = strings.Replace(field, "Url", "URL", -1)
+	// GoLint spec
+	field = strings.Replace(field, "Id", "ID", -1)
+	return field
+}
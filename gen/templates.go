@@ -93,17 +93,17 @@ func (a *{{ $.ParentName }}) Set{{ .Name }}(value {{ if .IsSlice}}[]{{if .IsPoin
 // Define how get methods are templatized
 var updateMethod = template.Must(structFormat.New("updateMethod").Parse(`
 {{ with .Field }}
-// Update{{.Name }} will update a sequence at index.  If indx is greater than the
-// length of the sequence, we add it to the end.
+// Update{{.Name }} will update a sequence at index.  If index is outside of the
+// sequence, we add it to the end.
 // Usage: 
 // value := {{ .Type }}{ }
 // a.Update{{ .Name }}(value, 2)
 func (a *{{ $.ParentName }}) Update{{ .Name }}(value {{if .IsPointer}}*{{end}}{{ .Type }}, index int) {
-    current := a.Get{{.Name}}()
-    if len(current) > index {
+    if index >= 0 && index < len(a.{{ .Name }}) {
         a.{{ .Name }}[index] = value
+        return
     }
-    a.{{ .Name }} = append(current, value)
+    a.{{ .Name }} = append(a.{{ .Name }}, value)
 }
 // Add{{.Name }} adds a new element to the sequence.  If the sequence is nil, it is created.
 // Usage: 
@@ -112,6 +112,60 @@ func (a *{{ $.ParentName }}) Update{{ .Name }}(value {{if .IsPointer}}*{{end}}{{
 func (a *{{ $.ParentName }}) Add{{ .Name }}(value {{if .IsPointer}}*{{end}}{{ .Type }}) {
     a.{{ .Name }} = append(a.{{ .Name }}, value)
 }
+// Insert{{.Name }} adds a new element to the sequence before index.  If index is
+// past the end of the sequence, the element is added to the end.
+// Usage: 
+// value := {{ .Type }}{ }
+// a.Insert{{ .Name }}(value, 0)
+func (a *{{ $.ParentName }}) Insert{{ .Name }}(value {{if .IsPointer}}*{{end}}{{ .Type }}, index int) {
+    if index < 0 {
+        index = 0
+    }
+    if index >= len(a.{{ .Name }}) {
+        a.{{ .Name }} = append(a.{{ .Name }}, value)
+        return
+    }
+    a.{{ .Name }} = append(a.{{ .Name }}[:index], append([]{{if .IsPointer}}*{{end}}{{ .Type }}{value}, a.{{ .Name }}[index:]...)...)
+}
+// Remove{{.Name }} removes the element at index from the sequence, and reports
+// whether there was one to remove.
+// Usage: 
+// a.Remove{{ .Name }}(2)
+func (a *{{ $.ParentName }}) Remove{{ .Name }}(index int) bool {
+    if index < 0 || index >= len(a.{{ .Name }}) {
+        return false
+    }
+    a.{{ .Name }} = append(a.{{ .Name }}[:index], a.{{ .Name }}[index+1:]...)
+    return true
+}
+// Find{{.Name }} returns the index of the first element of the sequence that
+// match returns true for, along with the element.  The index is -1 if there is none.
+// Usage: 
+// index, value := a.Find{{ .Name }}(func(value {{if .IsPointer}}*{{end}}{{ .Type }}) bool { return value != nil })
+func (a *{{ $.ParentName }}) Find{{ .Name }}(match func({{if .IsPointer}}*{{end}}{{ .Type }}) bool) (int, {{if .IsPointer}}*{{end}}{{ .Type }}) {
+    for index, value := range a.{{ .Name }} {
+        if match(value) {
+            return index, value
+        }
+    }
+    {{- if .IsPointer }}
+    return -1, nil
+    {{- else }}
+    return -1, {{ .DefaultValue }}
+    {{- end }}
+}
+// Len{{.Name }} returns the number of elements in the sequence.
+// Usage: 
+// count := a.Len{{ .Name }}()
+func (a *{{ $.ParentName }}) Len{{ .Name }}() int {
+    return len(a.{{ .Name }})
+}
+// Clear{{.Name }} removes every element from the sequence.
+// Usage: 
+// a.Clear{{ .Name }}()
+func (a *{{ $.ParentName }}) Clear{{ .Name }}() {
+    a.{{ .Name }} = nil
+}
 {{ end }}
 `))
 
@@ -121,21 +175,26 @@ var modelFormat = template.Must(template.New("parent").Parse(`// Package pom Cod
 package pom
 
 // XMLInner describes the 'any' type field in XML, which is effectively untyped.
-// We just take whatever is in that field and unmarshal it directly
+// We just take whatever is in that field and unmarshal it directly.
+// Attributes on the element itself (like combine.children) are kept in Attrs
 type XMLInner struct {
-	InnerXML string ` + "`xml:\",innerxml\"`" + `
+	Attrs    []xml.Attr ` + "`xml:\",any,attr\"`" + `
+	InnerXML string     ` + "`xml:\",innerxml\"`" + `
 }
 
 // XMLProperties is the subtype for POM Properties.
 // In the XSD, properties are defined as an "Any" type
 // However, this anytype has a consistent format.
 // So it isn't an anytype...despite saying so...
+// Comment holds any comments after the last property.
 type XMLProperties struct {
 	Comment  xml.Comment        ` + "`xml:\",comment\"`" + `
 	Elements []XMLPropertiesEntry ` + "`xml:\",any\"`" + `
 }
 
 // XMLPropertiesEntry contains the actual value of the properties
+// Comment holds the comments written just before the property (or inside of it),
+// so they stay with the property when it is moved or removed.
 type XMLPropertiesEntry struct {
 	XMLName xml.Name
 	Value   string ` + "`xml:\",chardata\"`" + `
@@ -150,51 +209,174 @@ func (m XMLPropertiesEntry) MarshalXML(e *xml.Encoder, start xml.StartElement) e
 	return e.Encode(xmlMapEntry{XMLName: xml.Name{Local: m.XMLName.Local, Space: ""}, Value: m.Value})
 }
 
+// UnmarshalXML reads the properties in order, attaching each comment to the property after it
+func (m *XMLProperties) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var comments xml.Comment
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch t := token.(type) {
+		case xml.Comment:
+			comments = append(comments, t...)
+		case xml.StartElement:
+			entry := XMLPropertiesEntry{}
+			if err := d.DecodeElement(&entry, &t); err != nil {
+				return err
+			}
+			entry.Comment = append(comments, entry.Comment...)
+			comments = nil
+			m.Elements = append(m.Elements, entry)
+		case xml.EndElement:
+			m.Comment = comments
+			return nil
+		}
+	}
+}
+
+// MarshalXML writes each property with its comments in front of it, each on a line of its own
+func (m XMLProperties) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.EncodeElement(m.layout(), start)
+}
+
 // XMLMap is a custom key used to let XML data parse maps
 // Because it doesnt do that by default...for some reason.
+// Go maps have no order, so use XMLOrderedMap to keep the order the keys were read in.
 type XMLMap map[string]string
 
 type xmlMapEntry struct {
-    XMLName xml.Name
-    Value   string ` + "`xml:\",chardata\"`" + `
+	XMLName xml.Name
+	Value   string ` + "`xml:\",chardata\"`" + `
 }
 
 // MarshalXML marshals the map to XML, with each key in the map being a
 // tag and it's corresponding value being it's contents.
+// Keys are sorted, so the same map always marshals to the same bytes.
 func (m XMLMap) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
-    if len(m) == 0 {
-        return nil
-    }
+	if len(m) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
 
-    err := e.EncodeToken(start)
-    if err != nil {
-        return err
-    }
+	ordered := XMLOrderedMap{}
+	for _, k := range keys {
+		ordered.Set(k, m[k])
+	}
+	return ordered.MarshalXML(e, start)
+}
 
-    for k, v := range m {
-        e.Encode(xmlMapEntry{XMLName: xml.Name{Local: k}, Value: v})
-    }
+// UnmarshalXML takes a key and turns it into a map
+func (m *XMLMap) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	ordered := XMLOrderedMap{}
+	if err := ordered.UnmarshalXML(d, start); err != nil {
+		return err
+	}
+	*m = XMLMap{}
+	for _, entry := range ordered.Entries {
+		(*m)[entry.Key] = entry.Value
+	}
+	return nil
+}
 
-    return e.EncodeToken(start.End())
+// XMLOrderedMap lets XML data parse maps like XMLMap does, but keeps the keys
+// in the order they were read or set in, and marshals them back out in that order.
+type XMLOrderedMap struct {
+	Entries []XMLMapEntry
 }
 
+// XMLMapEntry is a single key and value of an XMLOrderedMap
+type XMLMapEntry struct {
+	Key   string
+	Value string
+}
 
-// UnmarshalXML takes a key and turns it into a map
-func (m *XMLMap) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
-    *m = XMLMap{}
-    for {
-        var e xmlMapEntry
-
-        err := d.Decode(&e)
-        if err == io.EOF {
-            break
-        } else if err != nil {
-            return err
-        }
+// Get returns the value of key
+func (m *XMLOrderedMap) Get(key string) (string, bool) {
+	for _, entry := range m.Entries {
+		if entry.Key == key {
+			return entry.Value, true
+		}
+	}
+	return "", false
+}
 
-        (*m)[e.XMLName.Local] = e.Value
-    }
-    return nil
+// Set changes the value of key in place, or adds it to the end if it is new
+func (m *XMLOrderedMap) Set(key, value string) {
+	for i := range m.Entries {
+		if m.Entries[i].Key == key {
+			m.Entries[i].Value = value
+			return
+		}
+	}
+	m.Entries = append(m.Entries, XMLMapEntry{Key: key, Value: value})
+}
+
+// Delete removes key, and reports whether it was there
+func (m *XMLOrderedMap) Delete(key string) bool {
+	for i := range m.Entries {
+		if m.Entries[i].Key == key {
+			m.Entries = append(m.Entries[:i], m.Entries[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Keys returns every key, in order
+func (m *XMLOrderedMap) Keys() []string {
+	keys := make([]string, 0, len(m.Entries))
+	for _, entry := range m.Entries {
+		keys = append(keys, entry.Key)
+	}
+	return keys
+}
+
+// Len returns the number of keys
+func (m *XMLOrderedMap) Len() int {
+	return len(m.Entries)
+}
+
+// MarshalXML writes each key as a tag holding its value, in order
+func (m XMLOrderedMap) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if len(m.Entries) == 0 {
+		return nil
+	}
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	for _, entry := range m.Entries {
+		if err := e.Encode(xmlMapEntry{XMLName: xml.Name{Local: entry.Key}, Value: entry.Value}); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+// UnmarshalXML reads each tag inside of start as a key.  If a key is
+// repeated, it keeps its first position and takes the last value.
+func (m *XMLOrderedMap) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	m.Entries = nil
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			var entry xmlMapEntry
+			if err := d.DecodeElement(&entry, &t); err != nil {
+				return err
+			}
+			m.Set(entry.XMLName.Local, entry.Value)
+		case xml.EndElement:
+			return nil
+		}
+	}
 }
 
 // Workaround to get the project inside a pom to marshal/unmarshel correctly
@@ -1,29 +1,34 @@
 // Package pom Code generated DO NOT EDIT
 // This file was generated by robots at
-// 2019-08-30 10:50:30.772984 -0700 PDT m=+1.548791801
+// 2026-10-18 14:21:07.518933 -0700 PDT m=+1.301127402
 package pom
 
 import (
 	"encoding/xml"
-	"io"
+	"sort"
 )
 
 // XMLInner describes the 'any' type field in XML, which is effectively untyped.
-// We just take whatever is in that field and unmarshal it directly
+// We just take whatever is in that field and unmarshal it directly.
+// Attributes on the element itself (like combine.children) are kept in Attrs
 type XMLInner struct {
-	InnerXML string `xml:",innerxml"`
+	Attrs    []xml.Attr `xml:",any,attr"`
+	InnerXML string     `xml:",innerxml"`
 }
 
 // XMLProperties is the subtype for POM Properties.
 // In the XSD, properties are defined as an "Any" type
 // However, this anytype has a consistent format.
 // So it isn't an anytype...despite saying so...
+// Comment holds any comments after the last property.
 type XMLProperties struct {
 	Comment  xml.Comment          `xml:",comment"`
 	Elements []XMLPropertiesEntry `xml:",any"`
 }
 
 // XMLPropertiesEntry contains the actual value of the properties
+// Comment holds the comments written just before the property (or inside of it),
+// so they stay with the property when it is moved or removed.
 type XMLPropertiesEntry struct {
 	XMLName xml.Name
 	Value   string      `xml:",chardata"`
@@ -38,8 +43,40 @@ func (m XMLPropertiesEntry) MarshalXML(e *xml.Encoder, start xml.StartElement) e
 	return e.Encode(xmlMapEntry{XMLName: xml.Name{Local: m.XMLName.Local, Space: ""}, Value: m.Value})
 }
 
+// UnmarshalXML reads the properties in order, attaching each comment to the property after it
+func (m *XMLProperties) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var comments xml.Comment
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch t := token.(type) {
+		case xml.Comment:
+			comments = append(comments, t...)
+		case xml.StartElement:
+			entry := XMLPropertiesEntry{}
+			if err := d.DecodeElement(&entry, &t); err != nil {
+				return err
+			}
+			entry.Comment = append(comments, entry.Comment...)
+			comments = nil
+			m.Elements = append(m.Elements, entry)
+		case xml.EndElement:
+			m.Comment = comments
+			return nil
+		}
+	}
+}
+
+// MarshalXML writes each property with its comments in front of it, each on a line of its own
+func (m XMLProperties) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.EncodeElement(m.layout(), start)
+}
+
 // XMLMap is a custom key used to let XML data parse maps
 // Because it doesnt do that by default...for some reason.
+// Go maps have no order, so use XMLOrderedMap to keep the order the keys were read in.
 type XMLMap map[string]string
 
 type xmlMapEntry struct {
@@ -49,39 +86,131 @@ type xmlMapEntry struct {
 
 // MarshalXML marshals the map to XML, with each key in the map being a
 // tag and it's corresponding value being it's contents.
+// Keys are sorted, so the same map always marshals to the same bytes.
 func (m XMLMap) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 	if len(m) == 0 {
 		return nil
 	}
-
-	err := e.EncodeToken(start)
-	if err != nil {
-		return err
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
 	}
+	sort.Strings(keys)
 
-	for k, v := range m {
-		e.Encode(xmlMapEntry{XMLName: xml.Name{Local: k}, Value: v})
+	ordered := XMLOrderedMap{}
+	for _, k := range keys {
+		ordered.Set(k, m[k])
 	}
-
-	return e.EncodeToken(start.End())
+	return ordered.MarshalXML(e, start)
 }
 
 // UnmarshalXML takes a key and turns it into a map
 func (m *XMLMap) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	ordered := XMLOrderedMap{}
+	if err := ordered.UnmarshalXML(d, start); err != nil {
+		return err
+	}
 	*m = XMLMap{}
-	for {
-		var e xmlMapEntry
+	for _, entry := range ordered.Entries {
+		(*m)[entry.Key] = entry.Value
+	}
+	return nil
+}
+
+// XMLOrderedMap lets XML data parse maps like XMLMap does, but keeps the keys
+// in the order they were read or set in, and marshals them back out in that order.
+type XMLOrderedMap struct {
+	Entries []XMLMapEntry
+}
+
+// XMLMapEntry is a single key and value of an XMLOrderedMap
+type XMLMapEntry struct {
+	Key   string
+	Value string
+}
+
+// Get returns the value of key
+func (m *XMLOrderedMap) Get(key string) (string, bool) {
+	for _, entry := range m.Entries {
+		if entry.Key == key {
+			return entry.Value, true
+		}
+	}
+	return "", false
+}
+
+// Set changes the value of key in place, or adds it to the end if it is new
+func (m *XMLOrderedMap) Set(key, value string) {
+	for i := range m.Entries {
+		if m.Entries[i].Key == key {
+			m.Entries[i].Value = value
+			return
+		}
+	}
+	m.Entries = append(m.Entries, XMLMapEntry{Key: key, Value: value})
+}
 
-		err := d.Decode(&e)
-		if err == io.EOF {
-			break
-		} else if err != nil {
+// Delete removes key, and reports whether it was there
+func (m *XMLOrderedMap) Delete(key string) bool {
+	for i := range m.Entries {
+		if m.Entries[i].Key == key {
+			m.Entries = append(m.Entries[:i], m.Entries[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Keys returns every key, in order
+func (m *XMLOrderedMap) Keys() []string {
+	keys := make([]string, 0, len(m.Entries))
+	for _, entry := range m.Entries {
+		keys = append(keys, entry.Key)
+	}
+	return keys
+}
+
+// Len returns the number of keys
+func (m *XMLOrderedMap) Len() int {
+	return len(m.Entries)
+}
+
+// MarshalXML writes each key as a tag holding its value, in order
+func (m XMLOrderedMap) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if len(m.Entries) == 0 {
+		return nil
+	}
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	for _, entry := range m.Entries {
+		if err := e.Encode(xmlMapEntry{XMLName: xml.Name{Local: entry.Key}, Value: entry.Value}); err != nil {
 			return err
 		}
+	}
+	return e.EncodeToken(start.End())
+}
 
-		(*m)[e.XMLName.Local] = e.Value
+// UnmarshalXML reads each tag inside of start as a key.  If a key is
+// repeated, it keeps its first position and takes the last value.
+func (m *XMLOrderedMap) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	m.Entries = nil
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			var entry xmlMapEntry
+			if err := d.DecodeElement(&entry, &t); err != nil {
+				return err
+			}
+			m.Set(entry.XMLName.Local, entry.Value)
+		case xml.EndElement:
+			return nil
+		}
 	}
-	return nil
 }
 
 // Workaround to get the project inside a pom to marshal/unmarshel correctly
@@ -137,17 +266,17 @@ func (a *SequenceLicense) SetLicense(value []*License) {
 
 }
 
-// UpdateLicense will update a sequence at index.  If indx is greater than the
-// length of the sequence, we add it to the end.
+// UpdateLicense will update a sequence at index.  If index is outside of the
+// sequence, we add it to the end.
 // Usage:
 // value := License{ }
 // a.UpdateLicense(value, 2)
 func (a *SequenceLicense) UpdateLicense(value *License, index int) {
-	current := a.GetLicense()
-	if len(current) > index {
+	if index >= 0 && index < len(a.License) {
 		a.License[index] = value
+		return
 	}
-	a.License = append(current, value)
+	a.License = append(a.License, value)
 }
 
 // AddLicense adds a new element to the sequence.  If the sequence is nil, it is created.
@@ -158,6 +287,61 @@ func (a *SequenceLicense) AddLicense(value *License) {
 	a.License = append(a.License, value)
 }
 
+// InsertLicense adds a new element to the sequence before index.  If index is
+// past the end of the sequence, the element is added to the end.
+// Usage:
+// value := License{ }
+// a.InsertLicense(value, 0)
+func (a *SequenceLicense) InsertLicense(value *License, index int) {
+	if index < 0 {
+		index = 0
+	}
+	if index >= len(a.License) {
+		a.License = append(a.License, value)
+		return
+	}
+	a.License = append(a.License[:index], append([]*License{value}, a.License[index:]...)...)
+}
+
+// RemoveLicense removes the element at index from the sequence, and reports
+// whether there was one to remove.
+// Usage:
+// a.RemoveLicense(2)
+func (a *SequenceLicense) RemoveLicense(index int) bool {
+	if index < 0 || index >= len(a.License) {
+		return false
+	}
+	a.License = append(a.License[:index], a.License[index+1:]...)
+	return true
+}
+
+// FindLicense returns the index of the first element of the sequence that
+// match returns true for, along with the element.  The index is -1 if there is none.
+// Usage:
+// index, value := a.FindLicense(func(value *License) bool { return value != nil })
+func (a *SequenceLicense) FindLicense(match func(*License) bool) (int, *License) {
+	for index, value := range a.License {
+		if match(value) {
+			return index, value
+		}
+	}
+	return -1, nil
+}
+
+// LenLicense returns the number of elements in the sequence.
+// Usage:
+// count := a.LenLicense()
+func (a *SequenceLicense) LenLicense() int {
+	return len(a.License)
+}
+
+// ClearLicense removes every element from the sequence.
+// Usage:
+// a.ClearLicense()
+func (a *SequenceLicense) ClearLicense() {
+	a.License = nil
+}
+
 // SequenceDeveloper contains the subelements for iterables in XML
 type SequenceDeveloper struct {
 	Comment string `xml:",comment"`
@@ -206,17 +390,17 @@ func (a *SequenceDeveloper) SetDeveloper(value []*Developer) {
 
 }
 
-// UpdateDeveloper will update a sequence at index.  If indx is greater than the
-// length of the sequence, we add it to the end.
+// UpdateDeveloper will update a sequence at index.  If index is outside of the
+// sequence, we add it to the end.
 // Usage:
 // value := Developer{ }
 // a.UpdateDeveloper(value, 2)
 func (a *SequenceDeveloper) UpdateDeveloper(value *Developer, index int) {
-	current := a.GetDeveloper()
-	if len(current) > index {
+	if index >= 0 && index < len(a.Developer) {
 		a.Developer[index] = value
+		return
 	}
-	a.Developer = append(current, value)
+	a.Developer = append(a.Developer, value)
 }
 
 // AddDeveloper adds a new element to the sequence.  If the sequence is nil, it is created.
@@ -227,6 +411,61 @@ func (a *SequenceDeveloper) AddDeveloper(value *Developer) {
 	a.Developer = append(a.Developer, value)
 }
 
+// InsertDeveloper adds a new element to the sequence before index.  If index is
+// past the end of the sequence, the element is added to the end.
+// Usage:
+// value := Developer{ }
+// a.InsertDeveloper(value, 0)
+func (a *SequenceDeveloper) InsertDeveloper(value *Developer, index int) {
+	if index < 0 {
+		index = 0
+	}
+	if index >= len(a.Developer) {
+		a.Developer = append(a.Developer, value)
+		return
+	}
+	a.Developer = append(a.Developer[:index], append([]*Developer{value}, a.Developer[index:]...)...)
+}
+
+// RemoveDeveloper removes the element at index from the sequence, and reports
+// whether there was one to remove.
+// Usage:
+// a.RemoveDeveloper(2)
+func (a *SequenceDeveloper) RemoveDeveloper(index int) bool {
+	if index < 0 || index >= len(a.Developer) {
+		return false
+	}
+	a.Developer = append(a.Developer[:index], a.Developer[index+1:]...)
+	return true
+}
+
+// FindDeveloper returns the index of the first element of the sequence that
+// match returns true for, along with the element.  The index is -1 if there is none.
+// Usage:
+// index, value := a.FindDeveloper(func(value *Developer) bool { return value != nil })
+func (a *SequenceDeveloper) FindDeveloper(match func(*Developer) bool) (int, *Developer) {
+	for index, value := range a.Developer {
+		if match(value) {
+			return index, value
+		}
+	}
+	return -1, nil
+}
+
+// LenDeveloper returns the number of elements in the sequence.
+// Usage:
+// count := a.LenDeveloper()
+func (a *SequenceDeveloper) LenDeveloper() int {
+	return len(a.Developer)
+}
+
+// ClearDeveloper removes every element from the sequence.
+// Usage:
+// a.ClearDeveloper()
+func (a *SequenceDeveloper) ClearDeveloper() {
+	a.Developer = nil
+}
+
 // SequenceContributor contains the subelements for iterables in XML
 type SequenceContributor struct {
 	Comment string `xml:",comment"`
@@ -275,17 +514,17 @@ func (a *SequenceContributor) SetContributor(value []*Contributor) {
 
 }
 
-// UpdateContributor will update a sequence at index.  If indx is greater than the
-// length of the sequence, we add it to the end.
+// UpdateContributor will update a sequence at index.  If index is outside of the
+// sequence, we add it to the end.
 // Usage:
 // value := Contributor{ }
 // a.UpdateContributor(value, 2)
 func (a *SequenceContributor) UpdateContributor(value *Contributor, index int) {
-	current := a.GetContributor()
-	if len(current) > index {
+	if index >= 0 && index < len(a.Contributor) {
 		a.Contributor[index] = value
+		return
 	}
-	a.Contributor = append(current, value)
+	a.Contributor = append(a.Contributor, value)
 }
 
 // AddContributor adds a new element to the sequence.  If the sequence is nil, it is created.
@@ -296,6 +535,61 @@ func (a *SequenceContributor) AddContributor(value *Contributor) {
 	a.Contributor = append(a.Contributor, value)
 }
 
+// InsertContributor adds a new element to the sequence before index.  If index is
+// past the end of the sequence, the element is added to the end.
+// Usage:
+// value := Contributor{ }
+// a.InsertContributor(value, 0)
+func (a *SequenceContributor) InsertContributor(value *Contributor, index int) {
+	if index < 0 {
+		index = 0
+	}
+	if index >= len(a.Contributor) {
+		a.Contributor = append(a.Contributor, value)
+		return
+	}
+	a.Contributor = append(a.Contributor[:index], append([]*Contributor{value}, a.Contributor[index:]...)...)
+}
+
+// RemoveContributor removes the element at index from the sequence, and reports
+// whether there was one to remove.
+// Usage:
+// a.RemoveContributor(2)
+func (a *SequenceContributor) RemoveContributor(index int) bool {
+	if index < 0 || index >= len(a.Contributor) {
+		return false
+	}
+	a.Contributor = append(a.Contributor[:index], a.Contributor[index+1:]...)
+	return true
+}
+
+// FindContributor returns the index of the first element of the sequence that
+// match returns true for, along with the element.  The index is -1 if there is none.
+// Usage:
+// index, value := a.FindContributor(func(value *Contributor) bool { return value != nil })
+func (a *SequenceContributor) FindContributor(match func(*Contributor) bool) (int, *Contributor) {
+	for index, value := range a.Contributor {
+		if match(value) {
+			return index, value
+		}
+	}
+	return -1, nil
+}
+
+// LenContributor returns the number of elements in the sequence.
+// Usage:
+// count := a.LenContributor()
+func (a *SequenceContributor) LenContributor() int {
+	return len(a.Contributor)
+}
+
+// ClearContributor removes every element from the sequence.
+// Usage:
+// a.ClearContributor()
+func (a *SequenceContributor) ClearContributor() {
+	a.Contributor = nil
+}
+
 // SequenceMailingList contains the subelements for iterables in XML
 type SequenceMailingList struct {
 	Comment string `xml:",comment"`
@@ -344,17 +638,17 @@ func (a *SequenceMailingList) SetMailingList(value []*MailingList) {
 
 }
 
-// UpdateMailingList will update a sequence at index.  If indx is greater than the
-// length of the sequence, we add it to the end.
+// UpdateMailingList will update a sequence at index.  If index is outside of the
+// sequence, we add it to the end.
 // Usage:
 // value := MailingList{ }
 // a.UpdateMailingList(value, 2)
 func (a *SequenceMailingList) UpdateMailingList(value *MailingList, index int) {
-	current := a.GetMailingList()
-	if len(current) > index {
+	if index >= 0 && index < len(a.MailingList) {
 		a.MailingList[index] = value
+		return
 	}
-	a.MailingList = append(current, value)
+	a.MailingList = append(a.MailingList, value)
 }
 
 // AddMailingList adds a new element to the sequence.  If the sequence is nil, it is created.
@@ -365,6 +659,61 @@ func (a *SequenceMailingList) AddMailingList(value *MailingList) {
 	a.MailingList = append(a.MailingList, value)
 }
 
+// InsertMailingList adds a new element to the sequence before index.  If index is
+// past the end of the sequence, the element is added to the end.
+// Usage:
+// value := MailingList{ }
+// a.InsertMailingList(value, 0)
+func (a *SequenceMailingList) InsertMailingList(value *MailingList, index int) {
+	if index < 0 {
+		index = 0
+	}
+	if index >= len(a.MailingList) {
+		a.MailingList = append(a.MailingList, value)
+		return
+	}
+	a.MailingList = append(a.MailingList[:index], append([]*MailingList{value}, a.MailingList[index:]...)...)
+}
+
+// RemoveMailingList removes the element at index from the sequence, and reports
+// whether there was one to remove.
+// Usage:
+// a.RemoveMailingList(2)
+func (a *SequenceMailingList) RemoveMailingList(index int) bool {
+	if index < 0 || index >= len(a.MailingList) {
+		return false
+	}
+	a.MailingList = append(a.MailingList[:index], a.MailingList[index+1:]...)
+	return true
+}
+
+// FindMailingList returns the index of the first element of the sequence that
+// match returns true for, along with the element.  The index is -1 if there is none.
+// Usage:
+// index, value := a.FindMailingList(func(value *MailingList) bool { return value != nil })
+func (a *SequenceMailingList) FindMailingList(match func(*MailingList) bool) (int, *MailingList) {
+	for index, value := range a.MailingList {
+		if match(value) {
+			return index, value
+		}
+	}
+	return -1, nil
+}
+
+// LenMailingList returns the number of elements in the sequence.
+// Usage:
+// count := a.LenMailingList()
+func (a *SequenceMailingList) LenMailingList() int {
+	return len(a.MailingList)
+}
+
+// ClearMailingList removes every element from the sequence.
+// Usage:
+// a.ClearMailingList()
+func (a *SequenceMailingList) ClearMailingList() {
+	a.MailingList = nil
+}
+
 // SequenceModule contains the subelements for iterables in XML
 type SequenceModule struct {
 	Comment string `xml:",comment"`
@@ -413,17 +762,17 @@ func (a *SequenceModule) SetModule(value []*string) {
 
 }
 
-// UpdateModule will update a sequence at index.  If indx is greater than the
-// length of the sequence, we add it to the end.
+// UpdateModule will update a sequence at index.  If index is outside of the
+// sequence, we add it to the end.
 // Usage:
 // value := string{ }
 // a.UpdateModule(value, 2)
 func (a *SequenceModule) UpdateModule(value *string, index int) {
-	current := a.GetModule()
-	if len(current) > index {
+	if index >= 0 && index < len(a.Module) {
 		a.Module[index] = value
+		return
 	}
-	a.Module = append(current, value)
+	a.Module = append(a.Module, value)
 }
 
 // AddModule adds a new element to the sequence.  If the sequence is nil, it is created.
@@ -434,6 +783,61 @@ func (a *SequenceModule) AddModule(value *string) {
 	a.Module = append(a.Module, value)
 }
 
+// InsertModule adds a new element to the sequence before index.  If index is
+// past the end of the sequence, the element is added to the end.
+// Usage:
+// value := string{ }
+// a.InsertModule(value, 0)
+func (a *SequenceModule) InsertModule(value *string, index int) {
+	if index < 0 {
+		index = 0
+	}
+	if index >= len(a.Module) {
+		a.Module = append(a.Module, value)
+		return
+	}
+	a.Module = append(a.Module[:index], append([]*string{value}, a.Module[index:]...)...)
+}
+
+// RemoveModule removes the element at index from the sequence, and reports
+// whether there was one to remove.
+// Usage:
+// a.RemoveModule(2)
+func (a *SequenceModule) RemoveModule(index int) bool {
+	if index < 0 || index >= len(a.Module) {
+		return false
+	}
+	a.Module = append(a.Module[:index], a.Module[index+1:]...)
+	return true
+}
+
+// FindModule returns the index of the first element of the sequence that
+// match returns true for, along with the element.  The index is -1 if there is none.
+// Usage:
+// index, value := a.FindModule(func(value *string) bool { return value != nil })
+func (a *SequenceModule) FindModule(match func(*string) bool) (int, *string) {
+	for index, value := range a.Module {
+		if match(value) {
+			return index, value
+		}
+	}
+	return -1, nil
+}
+
+// LenModule returns the number of elements in the sequence.
+// Usage:
+// count := a.LenModule()
+func (a *SequenceModule) LenModule() int {
+	return len(a.Module)
+}
+
+// ClearModule removes every element from the sequence.
+// Usage:
+// a.ClearModule()
+func (a *SequenceModule) ClearModule() {
+	a.Module = nil
+}
+
 // SequenceDependency contains the subelements for iterables in XML
 type SequenceDependency struct {
 	Comment string `xml:",comment"`
@@ -482,17 +886,17 @@ func (a *SequenceDependency) SetDependency(value []*Dependency) {
 
 }
 
-// UpdateDependency will update a sequence at index.  If indx is greater than the
-// length of the sequence, we add it to the end.
+// UpdateDependency will update a sequence at index.  If index is outside of the
+// sequence, we add it to the end.
 // Usage:
 // value := Dependency{ }
 // a.UpdateDependency(value, 2)
 func (a *SequenceDependency) UpdateDependency(value *Dependency, index int) {
-	current := a.GetDependency()
-	if len(current) > index {
+	if index >= 0 && index < len(a.Dependency) {
 		a.Dependency[index] = value
+		return
 	}
-	a.Dependency = append(current, value)
+	a.Dependency = append(a.Dependency, value)
 }
 
 // AddDependency adds a new element to the sequence.  If the sequence is nil, it is created.
@@ -503,6 +907,61 @@ func (a *SequenceDependency) AddDependency(value *Dependency) {
 	a.Dependency = append(a.Dependency, value)
 }
 
+// InsertDependency adds a new element to the sequence before index.  If index is
+// past the end of the sequence, the element is added to the end.
+// Usage:
+// value := Dependency{ }
+// a.InsertDependency(value, 0)
+func (a *SequenceDependency) InsertDependency(value *Dependency, index int) {
+	if index < 0 {
+		index = 0
+	}
+	if index >= len(a.Dependency) {
+		a.Dependency = append(a.Dependency, value)
+		return
+	}
+	a.Dependency = append(a.Dependency[:index], append([]*Dependency{value}, a.Dependency[index:]...)...)
+}
+
+// RemoveDependency removes the element at index from the sequence, and reports
+// whether there was one to remove.
+// Usage:
+// a.RemoveDependency(2)
+func (a *SequenceDependency) RemoveDependency(index int) bool {
+	if index < 0 || index >= len(a.Dependency) {
+		return false
+	}
+	a.Dependency = append(a.Dependency[:index], a.Dependency[index+1:]...)
+	return true
+}
+
+// FindDependency returns the index of the first element of the sequence that
+// match returns true for, along with the element.  The index is -1 if there is none.
+// Usage:
+// index, value := a.FindDependency(func(value *Dependency) bool { return value != nil })
+func (a *SequenceDependency) FindDependency(match func(*Dependency) bool) (int, *Dependency) {
+	for index, value := range a.Dependency {
+		if match(value) {
+			return index, value
+		}
+	}
+	return -1, nil
+}
+
+// LenDependency returns the number of elements in the sequence.
+// Usage:
+// count := a.LenDependency()
+func (a *SequenceDependency) LenDependency() int {
+	return len(a.Dependency)
+}
+
+// ClearDependency removes every element from the sequence.
+// Usage:
+// a.ClearDependency()
+func (a *SequenceDependency) ClearDependency() {
+	a.Dependency = nil
+}
+
 // SequenceRepository contains the subelements for iterables in XML
 type SequenceRepository struct {
 	Comment string `xml:",comment"`
@@ -551,17 +1010,17 @@ func (a *SequenceRepository) SetRepository(value []*Repository) {
 
 }
 
-// UpdateRepository will update a sequence at index.  If indx is greater than the
-// length of the sequence, we add it to the end.
+// UpdateRepository will update a sequence at index.  If index is outside of the
+// sequence, we add it to the end.
 // Usage:
 // value := Repository{ }
 // a.UpdateRepository(value, 2)
 func (a *SequenceRepository) UpdateRepository(value *Repository, index int) {
-	current := a.GetRepository()
-	if len(current) > index {
+	if index >= 0 && index < len(a.Repository) {
 		a.Repository[index] = value
+		return
 	}
-	a.Repository = append(current, value)
+	a.Repository = append(a.Repository, value)
 }
 
 // AddRepository adds a new element to the sequence.  If the sequence is nil, it is created.
@@ -572,6 +1031,61 @@ func (a *SequenceRepository) AddRepository(value *Repository) {
 	a.Repository = append(a.Repository, value)
 }
 
+// InsertRepository adds a new element to the sequence before index.  If index is
+// past the end of the sequence, the element is added to the end.
+// Usage:
+// value := Repository{ }
+// a.InsertRepository(value, 0)
+func (a *SequenceRepository) InsertRepository(value *Repository, index int) {
+	if index < 0 {
+		index = 0
+	}
+	if index >= len(a.Repository) {
+		a.Repository = append(a.Repository, value)
+		return
+	}
+	a.Repository = append(a.Repository[:index], append([]*Repository{value}, a.Repository[index:]...)...)
+}
+
+// RemoveRepository removes the element at index from the sequence, and reports
+// whether there was one to remove.
+// Usage:
+// a.RemoveRepository(2)
+func (a *SequenceRepository) RemoveRepository(index int) bool {
+	if index < 0 || index >= len(a.Repository) {
+		return false
+	}
+	a.Repository = append(a.Repository[:index], a.Repository[index+1:]...)
+	return true
+}
+
+// FindRepository returns the index of the first element of the sequence that
+// match returns true for, along with the element.  The index is -1 if there is none.
+// Usage:
+// index, value := a.FindRepository(func(value *Repository) bool { return value != nil })
+func (a *SequenceRepository) FindRepository(match func(*Repository) bool) (int, *Repository) {
+	for index, value := range a.Repository {
+		if match(value) {
+			return index, value
+		}
+	}
+	return -1, nil
+}
+
+// LenRepository returns the number of elements in the sequence.
+// Usage:
+// count := a.LenRepository()
+func (a *SequenceRepository) LenRepository() int {
+	return len(a.Repository)
+}
+
+// ClearRepository removes every element from the sequence.
+// Usage:
+// a.ClearRepository()
+func (a *SequenceRepository) ClearRepository() {
+	a.Repository = nil
+}
+
 // SequencePluginRepository contains the subelements for iterables in XML
 type SequencePluginRepository struct {
 	Comment string `xml:",comment"`
@@ -620,17 +1134,17 @@ func (a *SequencePluginRepository) SetPluginRepository(value []*Repository) {
 
 }
 
-// UpdatePluginRepository will update a sequence at index.  If indx is greater than the
-// length of the sequence, we add it to the end.
+// UpdatePluginRepository will update a sequence at index.  If index is outside of the
+// sequence, we add it to the end.
 // Usage:
 // value := Repository{ }
 // a.UpdatePluginRepository(value, 2)
 func (a *SequencePluginRepository) UpdatePluginRepository(value *Repository, index int) {
-	current := a.GetPluginRepository()
-	if len(current) > index {
+	if index >= 0 && index < len(a.PluginRepository) {
 		a.PluginRepository[index] = value
+		return
 	}
-	a.PluginRepository = append(current, value)
+	a.PluginRepository = append(a.PluginRepository, value)
 }
 
 // AddPluginRepository adds a new element to the sequence.  If the sequence is nil, it is created.
@@ -641,6 +1155,61 @@ func (a *SequencePluginRepository) AddPluginRepository(value *Repository) {
 	a.PluginRepository = append(a.PluginRepository, value)
 }
 
+// InsertPluginRepository adds a new element to the sequence before index.  If index is
+// past the end of the sequence, the element is added to the end.
+// Usage:
+// value := Repository{ }
+// a.InsertPluginRepository(value, 0)
+func (a *SequencePluginRepository) InsertPluginRepository(value *Repository, index int) {
+	if index < 0 {
+		index = 0
+	}
+	if index >= len(a.PluginRepository) {
+		a.PluginRepository = append(a.PluginRepository, value)
+		return
+	}
+	a.PluginRepository = append(a.PluginRepository[:index], append([]*Repository{value}, a.PluginRepository[index:]...)...)
+}
+
+// RemovePluginRepository removes the element at index from the sequence, and reports
+// whether there was one to remove.
+// Usage:
+// a.RemovePluginRepository(2)
+func (a *SequencePluginRepository) RemovePluginRepository(index int) bool {
+	if index < 0 || index >= len(a.PluginRepository) {
+		return false
+	}
+	a.PluginRepository = append(a.PluginRepository[:index], a.PluginRepository[index+1:]...)
+	return true
+}
+
+// FindPluginRepository returns the index of the first element of the sequence that
+// match returns true for, along with the element.  The index is -1 if there is none.
+// Usage:
+// index, value := a.FindPluginRepository(func(value *Repository) bool { return value != nil })
+func (a *SequencePluginRepository) FindPluginRepository(match func(*Repository) bool) (int, *Repository) {
+	for index, value := range a.PluginRepository {
+		if match(value) {
+			return index, value
+		}
+	}
+	return -1, nil
+}
+
+// LenPluginRepository returns the number of elements in the sequence.
+// Usage:
+// count := a.LenPluginRepository()
+func (a *SequencePluginRepository) LenPluginRepository() int {
+	return len(a.PluginRepository)
+}
+
+// ClearPluginRepository removes every element from the sequence.
+// Usage:
+// a.ClearPluginRepository()
+func (a *SequencePluginRepository) ClearPluginRepository() {
+	a.PluginRepository = nil
+}
+
 // SequenceProfile contains the subelements for iterables in XML
 type SequenceProfile struct {
 	Comment string `xml:",comment"`
@@ -689,17 +1258,17 @@ func (a *SequenceProfile) SetProfile(value []*Profile) {
 
 }
 
-// UpdateProfile will update a sequence at index.  If indx is greater than the
-// length of the sequence, we add it to the end.
+// UpdateProfile will update a sequence at index.  If index is outside of the
+// sequence, we add it to the end.
 // Usage:
 // value := Profile{ }
 // a.UpdateProfile(value, 2)
 func (a *SequenceProfile) UpdateProfile(value *Profile, index int) {
-	current := a.GetProfile()
-	if len(current) > index {
+	if index >= 0 && index < len(a.Profile) {
 		a.Profile[index] = value
+		return
 	}
-	a.Profile = append(current, value)
+	a.Profile = append(a.Profile, value)
 }
 
 // AddProfile adds a new element to the sequence.  If the sequence is nil, it is created.
@@ -710,6 +1279,61 @@ func (a *SequenceProfile) AddProfile(value *Profile) {
 	a.Profile = append(a.Profile, value)
 }
 
+// InsertProfile adds a new element to the sequence before index.  If index is
+// past the end of the sequence, the element is added to the end.
+// Usage:
+// value := Profile{ }
+// a.InsertProfile(value, 0)
+func (a *SequenceProfile) InsertProfile(value *Profile, index int) {
+	if index < 0 {
+		index = 0
+	}
+	if index >= len(a.Profile) {
+		a.Profile = append(a.Profile, value)
+		return
+	}
+	a.Profile = append(a.Profile[:index], append([]*Profile{value}, a.Profile[index:]...)...)
+}
+
+// RemoveProfile removes the element at index from the sequence, and reports
+// whether there was one to remove.
+// Usage:
+// a.RemoveProfile(2)
+func (a *SequenceProfile) RemoveProfile(index int) bool {
+	if index < 0 || index >= len(a.Profile) {
+		return false
+	}
+	a.Profile = append(a.Profile[:index], a.Profile[index+1:]...)
+	return true
+}
+
+// FindProfile returns the index of the first element of the sequence that
+// match returns true for, along with the element.  The index is -1 if there is none.
+// Usage:
+// index, value := a.FindProfile(func(value *Profile) bool { return value != nil })
+func (a *SequenceProfile) FindProfile(match func(*Profile) bool) (int, *Profile) {
+	for index, value := range a.Profile {
+		if match(value) {
+			return index, value
+		}
+	}
+	return -1, nil
+}
+
+// LenProfile returns the number of elements in the sequence.
+// Usage:
+// count := a.LenProfile()
+func (a *SequenceProfile) LenProfile() int {
+	return len(a.Profile)
+}
+
+// ClearProfile removes every element from the sequence.
+// Usage:
+// a.ClearProfile()
+func (a *SequenceProfile) ClearProfile() {
+	a.Profile = nil
+}
+
 // Model The <code>&lt;project&gt;</code> element is the root of the descriptor.
 //        The following table lists all of the possible child elements.
 type Model struct {
@@ -854,7 +1478,18 @@ type Model struct {
 	   when activated.*/
 	Profiles *SequenceProfile `xml:"profiles,omitempty"`
 
+	/* Child.Project.Url.Inherit.Append.Path When children inherit from project's url, append path or not? Note: While the type
+	   of this field is <code>String</code> for technical reasons, the semantic type is actually
+	   <code>Boolean</code>
+	   <br><b>Default value is</b>: <code>true</code>
+	   <br><b>Since</b>: Maven 3.6.1*/
+	ChildProjectURLInheritAppendPath *string `xml:"child.project.url.inherit.append.path,attr,omitempty"`
+
 	Comment string `xml:",comment"`
+
+	/* Encoding The charset the POM was read in, like ISO-8859-1.  Marshal writes it back out
+	   in the same charset.  Empty means UTF-8.*/
+	Encoding string `xml:"-"`
 }
 
 // GetModelVersion Gets the value of ModelVersion and returns it.
@@ -1547,6 +2182,29 @@ func (a *Model) SetProfiles(value SequenceProfile) {
 
 }
 
+// GetChildProjectURLInheritAppendPath Gets the value of ChildProjectURLInheritAppendPath and returns it.
+// If the value does not exist, then the default empty value is returned
+// and exists is set to false
+// Usage:
+//   if value, ok := a.GetChildProjectURLInheritAppendPath(); ok {
+//        fmt.Println(value)
+//    }
+func (a *Model) GetChildProjectURLInheritAppendPath() (returnValue string, exists bool) {
+	if a.ChildProjectURLInheritAppendPath != nil {
+		return *a.ChildProjectURLInheritAppendPath, true
+	}
+	return "", false
+}
+
+// SetChildProjectURLInheritAppendPath will overwrite whatever value is currently set for ChildProjectURLInheritAppendPath.
+// Usage:
+// a.SetChildProjectURLInheritAppendPath("")
+func (a *Model) SetChildProjectURLInheritAppendPath(value string) {
+	copy := value
+	a.ChildProjectURLInheritAppendPath = &copy
+
+}
+
 // GetComment Gets the value of Comment and returns it.
 // If the value does not exist, then the default empty value is returned
 // and exists is set to false
@@ -1566,6 +2224,25 @@ func (a *Model) SetComment(value string) {
 
 }
 
+// GetEncoding Gets the value of Encoding and returns it.
+// If the value does not exist, then the default empty value is returned
+// and exists is set to false
+// Usage:
+//   if value, ok := a.GetEncoding(); ok {
+//        fmt.Println(value)
+//    }
+func (a *Model) GetEncoding() (returnValue string, exists bool) {
+	return a.Encoding, false
+}
+
+// SetEncoding will overwrite whatever value is currently set for Encoding.
+// Usage:
+// a.SetEncoding()
+func (a *Model) SetEncoding(value string) {
+	a.Encoding = value
+
+}
+
 // License Describes the licenses for this project. This is used to generate the license
 //        page of the project's web site, as well as being taken into consideration in other reporting
 //        and validation. The licenses listed for the project are that of the project itself, and not
@@ -1752,17 +2429,17 @@ func (a *SequenceNotifier) SetNotifier(value []*Notifier) {
 
 }
 
-// UpdateNotifier will update a sequence at index.  If indx is greater than the
-// length of the sequence, we add it to the end.
+// UpdateNotifier will update a sequence at index.  If index is outside of the
+// sequence, we add it to the end.
 // Usage:
 // value := Notifier{ }
 // a.UpdateNotifier(value, 2)
 func (a *SequenceNotifier) UpdateNotifier(value *Notifier, index int) {
-	current := a.GetNotifier()
-	if len(current) > index {
+	if index >= 0 && index < len(a.Notifier) {
 		a.Notifier[index] = value
+		return
 	}
-	a.Notifier = append(current, value)
+	a.Notifier = append(a.Notifier, value)
 }
 
 // AddNotifier adds a new element to the sequence.  If the sequence is nil, it is created.
@@ -1773,6 +2450,61 @@ func (a *SequenceNotifier) AddNotifier(value *Notifier) {
 	a.Notifier = append(a.Notifier, value)
 }
 
+// InsertNotifier adds a new element to the sequence before index.  If index is
+// past the end of the sequence, the element is added to the end.
+// Usage:
+// value := Notifier{ }
+// a.InsertNotifier(value, 0)
+func (a *SequenceNotifier) InsertNotifier(value *Notifier, index int) {
+	if index < 0 {
+		index = 0
+	}
+	if index >= len(a.Notifier) {
+		a.Notifier = append(a.Notifier, value)
+		return
+	}
+	a.Notifier = append(a.Notifier[:index], append([]*Notifier{value}, a.Notifier[index:]...)...)
+}
+
+// RemoveNotifier removes the element at index from the sequence, and reports
+// whether there was one to remove.
+// Usage:
+// a.RemoveNotifier(2)
+func (a *SequenceNotifier) RemoveNotifier(index int) bool {
+	if index < 0 || index >= len(a.Notifier) {
+		return false
+	}
+	a.Notifier = append(a.Notifier[:index], a.Notifier[index+1:]...)
+	return true
+}
+
+// FindNotifier returns the index of the first element of the sequence that
+// match returns true for, along with the element.  The index is -1 if there is none.
+// Usage:
+// index, value := a.FindNotifier(func(value *Notifier) bool { return value != nil })
+func (a *SequenceNotifier) FindNotifier(match func(*Notifier) bool) (int, *Notifier) {
+	for index, value := range a.Notifier {
+		if match(value) {
+			return index, value
+		}
+	}
+	return -1, nil
+}
+
+// LenNotifier returns the number of elements in the sequence.
+// Usage:
+// count := a.LenNotifier()
+func (a *SequenceNotifier) LenNotifier() int {
+	return len(a.Notifier)
+}
+
+// ClearNotifier removes every element from the sequence.
+// Usage:
+// a.ClearNotifier()
+func (a *SequenceNotifier) ClearNotifier() {
+	a.Notifier = nil
+}
+
 // CiManagement The <code>&lt;CiManagement&gt;</code> element contains informations required to the
 //        continuous integration system of the project.
 type CiManagement struct {
@@ -2114,6 +2846,27 @@ type Scm struct {
 	   scm's <code>child.scm.url.inherit.append.path="false"</code>*/
 	URL *string `xml:"url,omitempty"`
 
+	/* Child.Scm.Connection.Inherit.Append.Path When children inherit from scm connection, append path or not? Note: While the type
+	   of this field is <code>String</code> for technical reasons, the semantic type is actually
+	   <code>Boolean</code>
+	   <br><b>Default value is</b>: <code>true</code>
+	   <br><b>Since</b>: Maven 3.6.1*/
+	ChildScmConnectionInheritAppendPath *string `xml:"child.scm.connection.inherit.append.path,attr,omitempty"`
+
+	/* Child.Scm.DeveloperConnection.Inherit.Append.Path When children inherit from scm developer connection, append path or not? Note: While the type
+	   of this field is <code>String</code> for technical reasons, the semantic type is actually
+	   <code>Boolean</code>
+	   <br><b>Default value is</b>: <code>true</code>
+	   <br><b>Since</b>: Maven 3.6.1*/
+	ChildScmDeveloperConnectionInheritAppendPath *string `xml:"child.scm.developerConnection.inherit.append.path,attr,omitempty"`
+
+	/* Child.Scm.Url.Inherit.Append.Path When children inherit from scm url, append path or not? Note: While the type
+	   of this field is <code>String</code> for technical reasons, the semantic type is actually
+	   <code>Boolean</code>
+	   <br><b>Default value is</b>: <code>true</code>
+	   <br><b>Since</b>: Maven 3.6.1*/
+	ChildScmURLInheritAppendPath *string `xml:"child.scm.url.inherit.append.path,attr,omitempty"`
+
 	Comment string `xml:",comment"`
 }
 
@@ -2209,6 +2962,75 @@ func (a *Scm) SetURL(value string) {
 
 }
 
+// GetChildScmConnectionInheritAppendPath Gets the value of ChildScmConnectionInheritAppendPath and returns it.
+// If the value does not exist, then the default empty value is returned
+// and exists is set to false
+// Usage:
+//   if value, ok := a.GetChildScmConnectionInheritAppendPath(); ok {
+//        fmt.Println(value)
+//    }
+func (a *Scm) GetChildScmConnectionInheritAppendPath() (returnValue string, exists bool) {
+	if a.ChildScmConnectionInheritAppendPath != nil {
+		return *a.ChildScmConnectionInheritAppendPath, true
+	}
+	return "", false
+}
+
+// SetChildScmConnectionInheritAppendPath will overwrite whatever value is currently set for ChildScmConnectionInheritAppendPath.
+// Usage:
+// a.SetChildScmConnectionInheritAppendPath("")
+func (a *Scm) SetChildScmConnectionInheritAppendPath(value string) {
+	copy := value
+	a.ChildScmConnectionInheritAppendPath = &copy
+
+}
+
+// GetChildScmDeveloperConnectionInheritAppendPath Gets the value of ChildScmDeveloperConnectionInheritAppendPath and returns it.
+// If the value does not exist, then the default empty value is returned
+// and exists is set to false
+// Usage:
+//   if value, ok := a.GetChildScmDeveloperConnectionInheritAppendPath(); ok {
+//        fmt.Println(value)
+//    }
+func (a *Scm) GetChildScmDeveloperConnectionInheritAppendPath() (returnValue string, exists bool) {
+	if a.ChildScmDeveloperConnectionInheritAppendPath != nil {
+		return *a.ChildScmDeveloperConnectionInheritAppendPath, true
+	}
+	return "", false
+}
+
+// SetChildScmDeveloperConnectionInheritAppendPath will overwrite whatever value is currently set for ChildScmDeveloperConnectionInheritAppendPath.
+// Usage:
+// a.SetChildScmDeveloperConnectionInheritAppendPath("")
+func (a *Scm) SetChildScmDeveloperConnectionInheritAppendPath(value string) {
+	copy := value
+	a.ChildScmDeveloperConnectionInheritAppendPath = &copy
+
+}
+
+// GetChildScmURLInheritAppendPath Gets the value of ChildScmURLInheritAppendPath and returns it.
+// If the value does not exist, then the default empty value is returned
+// and exists is set to false
+// Usage:
+//   if value, ok := a.GetChildScmURLInheritAppendPath(); ok {
+//        fmt.Println(value)
+//    }
+func (a *Scm) GetChildScmURLInheritAppendPath() (returnValue string, exists bool) {
+	if a.ChildScmURLInheritAppendPath != nil {
+		return *a.ChildScmURLInheritAppendPath, true
+	}
+	return "", false
+}
+
+// SetChildScmURLInheritAppendPath will overwrite whatever value is currently set for ChildScmURLInheritAppendPath.
+// Usage:
+// a.SetChildScmURLInheritAppendPath("")
+func (a *Scm) SetChildScmURLInheritAppendPath(value string) {
+	copy := value
+	a.ChildScmURLInheritAppendPath = &copy
+
+}
+
 // GetComment Gets the value of Comment and returns it.
 // If the value does not exist, then the default empty value is returned
 // and exists is set to false
@@ -2408,17 +3230,17 @@ func (a *SequenceExclusion) SetExclusion(value []*Exclusion) {
 
 }
 
-// UpdateExclusion will update a sequence at index.  If indx is greater than the
-// length of the sequence, we add it to the end.
+// UpdateExclusion will update a sequence at index.  If index is outside of the
+// sequence, we add it to the end.
 // Usage:
 // value := Exclusion{ }
 // a.UpdateExclusion(value, 2)
 func (a *SequenceExclusion) UpdateExclusion(value *Exclusion, index int) {
-	current := a.GetExclusion()
-	if len(current) > index {
+	if index >= 0 && index < len(a.Exclusion) {
 		a.Exclusion[index] = value
+		return
 	}
-	a.Exclusion = append(current, value)
+	a.Exclusion = append(a.Exclusion, value)
 }
 
 // AddExclusion adds a new element to the sequence.  If the sequence is nil, it is created.
@@ -2429,6 +3251,61 @@ func (a *SequenceExclusion) AddExclusion(value *Exclusion) {
 	a.Exclusion = append(a.Exclusion, value)
 }
 
+// InsertExclusion adds a new element to the sequence before index.  If index is
+// past the end of the sequence, the element is added to the end.
+// Usage:
+// value := Exclusion{ }
+// a.InsertExclusion(value, 0)
+func (a *SequenceExclusion) InsertExclusion(value *Exclusion, index int) {
+	if index < 0 {
+		index = 0
+	}
+	if index >= len(a.Exclusion) {
+		a.Exclusion = append(a.Exclusion, value)
+		return
+	}
+	a.Exclusion = append(a.Exclusion[:index], append([]*Exclusion{value}, a.Exclusion[index:]...)...)
+}
+
+// RemoveExclusion removes the element at index from the sequence, and reports
+// whether there was one to remove.
+// Usage:
+// a.RemoveExclusion(2)
+func (a *SequenceExclusion) RemoveExclusion(index int) bool {
+	if index < 0 || index >= len(a.Exclusion) {
+		return false
+	}
+	a.Exclusion = append(a.Exclusion[:index], a.Exclusion[index+1:]...)
+	return true
+}
+
+// FindExclusion returns the index of the first element of the sequence that
+// match returns true for, along with the element.  The index is -1 if there is none.
+// Usage:
+// index, value := a.FindExclusion(func(value *Exclusion) bool { return value != nil })
+func (a *SequenceExclusion) FindExclusion(match func(*Exclusion) bool) (int, *Exclusion) {
+	for index, value := range a.Exclusion {
+		if match(value) {
+			return index, value
+		}
+	}
+	return -1, nil
+}
+
+// LenExclusion returns the number of elements in the sequence.
+// Usage:
+// count := a.LenExclusion()
+func (a *SequenceExclusion) LenExclusion() int {
+	return len(a.Exclusion)
+}
+
+// ClearExclusion removes every element from the sequence.
+// Usage:
+// a.ClearExclusion()
+func (a *SequenceExclusion) ClearExclusion() {
+	a.Exclusion = nil
+}
+
 // Dependency The <code>&lt;dependency&gt;</code> element contains information about a dependency
 //        of the project.
 type Dependency struct {
@@ -2993,17 +3870,17 @@ func (a *SequenceRole) SetRole(value []*string) {
 
 }
 
-// UpdateRole will update a sequence at index.  If indx is greater than the
-// length of the sequence, we add it to the end.
+// UpdateRole will update a sequence at index.  If index is outside of the
+// sequence, we add it to the end.
 // Usage:
 // value := string{ }
 // a.UpdateRole(value, 2)
 func (a *SequenceRole) UpdateRole(value *string, index int) {
-	current := a.GetRole()
-	if len(current) > index {
+	if index >= 0 && index < len(a.Role) {
 		a.Role[index] = value
+		return
 	}
-	a.Role = append(current, value)
+	a.Role = append(a.Role, value)
 }
 
 // AddRole adds a new element to the sequence.  If the sequence is nil, it is created.
@@ -3014,6 +3891,61 @@ func (a *SequenceRole) AddRole(value *string) {
 	a.Role = append(a.Role, value)
 }
 
+// InsertRole adds a new element to the sequence before index.  If index is
+// past the end of the sequence, the element is added to the end.
+// Usage:
+// value := string{ }
+// a.InsertRole(value, 0)
+func (a *SequenceRole) InsertRole(value *string, index int) {
+	if index < 0 {
+		index = 0
+	}
+	if index >= len(a.Role) {
+		a.Role = append(a.Role, value)
+		return
+	}
+	a.Role = append(a.Role[:index], append([]*string{value}, a.Role[index:]...)...)
+}
+
+// RemoveRole removes the element at index from the sequence, and reports
+// whether there was one to remove.
+// Usage:
+// a.RemoveRole(2)
+func (a *SequenceRole) RemoveRole(index int) bool {
+	if index < 0 || index >= len(a.Role) {
+		return false
+	}
+	a.Role = append(a.Role[:index], a.Role[index+1:]...)
+	return true
+}
+
+// FindRole returns the index of the first element of the sequence that
+// match returns true for, along with the element.  The index is -1 if there is none.
+// Usage:
+// index, value := a.FindRole(func(value *string) bool { return value != nil })
+func (a *SequenceRole) FindRole(match func(*string) bool) (int, *string) {
+	for index, value := range a.Role {
+		if match(value) {
+			return index, value
+		}
+	}
+	return -1, nil
+}
+
+// LenRole returns the number of elements in the sequence.
+// Usage:
+// count := a.LenRole()
+func (a *SequenceRole) LenRole() int {
+	return len(a.Role)
+}
+
+// ClearRole removes every element from the sequence.
+// Usage:
+// a.ClearRole()
+func (a *SequenceRole) ClearRole() {
+	a.Role = nil
+}
+
 // Developer Information about one of the committers on this project.
 type Developer struct {
 
@@ -3325,17 +4257,17 @@ func (a *SequenceOtherArchive) SetOtherArchive(value []*string) {
 
 }
 
-// UpdateOtherArchive will update a sequence at index.  If indx is greater than the
-// length of the sequence, we add it to the end.
+// UpdateOtherArchive will update a sequence at index.  If index is outside of the
+// sequence, we add it to the end.
 // Usage:
 // value := string{ }
 // a.UpdateOtherArchive(value, 2)
 func (a *SequenceOtherArchive) UpdateOtherArchive(value *string, index int) {
-	current := a.GetOtherArchive()
-	if len(current) > index {
+	if index >= 0 && index < len(a.OtherArchive) {
 		a.OtherArchive[index] = value
+		return
 	}
-	a.OtherArchive = append(current, value)
+	a.OtherArchive = append(a.OtherArchive, value)
 }
 
 // AddOtherArchive adds a new element to the sequence.  If the sequence is nil, it is created.
@@ -3346,6 +4278,61 @@ func (a *SequenceOtherArchive) AddOtherArchive(value *string) {
 	a.OtherArchive = append(a.OtherArchive, value)
 }
 
+// InsertOtherArchive adds a new element to the sequence before index.  If index is
+// past the end of the sequence, the element is added to the end.
+// Usage:
+// value := string{ }
+// a.InsertOtherArchive(value, 0)
+func (a *SequenceOtherArchive) InsertOtherArchive(value *string, index int) {
+	if index < 0 {
+		index = 0
+	}
+	if index >= len(a.OtherArchive) {
+		a.OtherArchive = append(a.OtherArchive, value)
+		return
+	}
+	a.OtherArchive = append(a.OtherArchive[:index], append([]*string{value}, a.OtherArchive[index:]...)...)
+}
+
+// RemoveOtherArchive removes the element at index from the sequence, and reports
+// whether there was one to remove.
+// Usage:
+// a.RemoveOtherArchive(2)
+func (a *SequenceOtherArchive) RemoveOtherArchive(index int) bool {
+	if index < 0 || index >= len(a.OtherArchive) {
+		return false
+	}
+	a.OtherArchive = append(a.OtherArchive[:index], a.OtherArchive[index+1:]...)
+	return true
+}
+
+// FindOtherArchive returns the index of the first element of the sequence that
+// match returns true for, along with the element.  The index is -1 if there is none.
+// Usage:
+// index, value := a.FindOtherArchive(func(value *string) bool { return value != nil })
+func (a *SequenceOtherArchive) FindOtherArchive(match func(*string) bool) (int, *string) {
+	for index, value := range a.OtherArchive {
+		if match(value) {
+			return index, value
+		}
+	}
+	return -1, nil
+}
+
+// LenOtherArchive returns the number of elements in the sequence.
+// Usage:
+// count := a.LenOtherArchive()
+func (a *SequenceOtherArchive) LenOtherArchive() int {
+	return len(a.OtherArchive)
+}
+
+// ClearOtherArchive removes every element from the sequence.
+// Usage:
+// a.ClearOtherArchive()
+func (a *SequenceOtherArchive) ClearOtherArchive() {
+	a.OtherArchive = nil
+}
+
 // MailingList This element describes all of the mailing lists associated with a project. The
 //        auto-generated site references this information.
 type MailingList struct {
@@ -4521,6 +5508,13 @@ type Site struct {
 	   site's <code>child.site.url.inherit.append.path="false"</code>*/
 	URL *string `xml:"url,omitempty"`
 
+	/* Child.Site.Url.Inherit.Append.Path When children inherit from distribution management site url, append path or not? Note: While the type
+	   of this field is <code>String</code> for technical reasons, the semantic type is actually
+	   <code>Boolean</code>
+	   <br><b>Default value is</b>: <code>true</code>
+	   <br><b>Since</b>: Maven 3.6.1*/
+	ChildSiteURLInheritAppendPath *string `xml:"child.site.url.inherit.append.path,attr,omitempty"`
+
 	Comment string `xml:",comment"`
 }
 
@@ -4593,6 +5587,29 @@ func (a *Site) SetURL(value string) {
 
 }
 
+// GetChildSiteURLInheritAppendPath Gets the value of ChildSiteURLInheritAppendPath and returns it.
+// If the value does not exist, then the default empty value is returned
+// and exists is set to false
+// Usage:
+//   if value, ok := a.GetChildSiteURLInheritAppendPath(); ok {
+//        fmt.Println(value)
+//    }
+func (a *Site) GetChildSiteURLInheritAppendPath() (returnValue string, exists bool) {
+	if a.ChildSiteURLInheritAppendPath != nil {
+		return *a.ChildSiteURLInheritAppendPath, true
+	}
+	return "", false
+}
+
+// SetChildSiteURLInheritAppendPath will overwrite whatever value is currently set for ChildSiteURLInheritAppendPath.
+// Usage:
+// a.SetChildSiteURLInheritAppendPath("")
+func (a *Site) SetChildSiteURLInheritAppendPath(value string) {
+	copy := value
+	a.ChildSiteURLInheritAppendPath = &copy
+
+}
+
 // GetComment Gets the value of Comment and returns it.
 // If the value does not exist, then the default empty value is returned
 // and exists is set to false
@@ -4612,8 +5629,8 @@ func (a *Site) SetComment(value string) {
 
 }
 
-// SequencePlugin contains the subelements for iterables in XML
-type SequencePlugin struct {
+// SequenceReportPlugin contains the subelements for iterables in XML
+type SequenceReportPlugin struct {
 	Comment string `xml:",comment"`
 
 	Plugin []*ReportPlugin `xml:"plugin,omitempty"`
@@ -4626,14 +5643,14 @@ type SequencePlugin struct {
 //   if value, ok := a.GetComment(); ok {
 //        fmt.Println(value)
 //    }
-func (a *SequencePlugin) GetComment() (returnValue string, exists bool) {
+func (a *SequenceReportPlugin) GetComment() (returnValue string, exists bool) {
 	return a.Comment, false
 }
 
 // SetComment will overwrite whatever value is currently set for Comment.
 // Usage:
 // a.SetComment()
-func (a *SequencePlugin) SetComment(value string) {
+func (a *SequenceReportPlugin) SetComment(value string) {
 	a.Comment = value
 
 }
@@ -4645,7 +5662,7 @@ func (a *SequencePlugin) SetComment(value string) {
 //   if value, ok := a.GetPlugin(); ok {
 //        fmt.Println(value)
 //    }
-func (a *SequencePlugin) GetPlugin() (returnValue []*ReportPlugin) {
+func (a *SequenceReportPlugin) GetPlugin() (returnValue []*ReportPlugin) {
 	if a.Plugin != nil {
 		return a.Plugin
 	}
@@ -4655,32 +5672,87 @@ func (a *SequencePlugin) GetPlugin() (returnValue []*ReportPlugin) {
 // SetPlugin will overwrite whatever value is currently set for Plugin.
 // Usage:
 // a.SetPlugin(ReportPlugin{})
-func (a *SequencePlugin) SetPlugin(value []*ReportPlugin) {
+func (a *SequenceReportPlugin) SetPlugin(value []*ReportPlugin) {
 	a.Plugin = value
 
 }
 
-// UpdatePlugin will update a sequence at index.  If indx is greater than the
-// length of the sequence, we add it to the end.
+// UpdatePlugin will update a sequence at index.  If index is outside of the
+// sequence, we add it to the end.
 // Usage:
 // value := ReportPlugin{ }
 // a.UpdatePlugin(value, 2)
-func (a *SequencePlugin) UpdatePlugin(value *ReportPlugin, index int) {
-	current := a.GetPlugin()
-	if len(current) > index {
+func (a *SequenceReportPlugin) UpdatePlugin(value *ReportPlugin, index int) {
+	if index >= 0 && index < len(a.Plugin) {
 		a.Plugin[index] = value
+		return
 	}
-	a.Plugin = append(current, value)
+	a.Plugin = append(a.Plugin, value)
 }
 
 // AddPlugin adds a new element to the sequence.  If the sequence is nil, it is created.
 // Usage:
 // value := ReportPlugin{ }
 // a.AddPlugin(value)
-func (a *SequencePlugin) AddPlugin(value *ReportPlugin) {
+func (a *SequenceReportPlugin) AddPlugin(value *ReportPlugin) {
 	a.Plugin = append(a.Plugin, value)
 }
 
+// InsertPlugin adds a new element to the sequence before index.  If index is
+// past the end of the sequence, the element is added to the end.
+// Usage:
+// value := ReportPlugin{ }
+// a.InsertPlugin(value, 0)
+func (a *SequenceReportPlugin) InsertPlugin(value *ReportPlugin, index int) {
+	if index < 0 {
+		index = 0
+	}
+	if index >= len(a.Plugin) {
+		a.Plugin = append(a.Plugin, value)
+		return
+	}
+	a.Plugin = append(a.Plugin[:index], append([]*ReportPlugin{value}, a.Plugin[index:]...)...)
+}
+
+// RemovePlugin removes the element at index from the sequence, and reports
+// whether there was one to remove.
+// Usage:
+// a.RemovePlugin(2)
+func (a *SequenceReportPlugin) RemovePlugin(index int) bool {
+	if index < 0 || index >= len(a.Plugin) {
+		return false
+	}
+	a.Plugin = append(a.Plugin[:index], a.Plugin[index+1:]...)
+	return true
+}
+
+// FindPlugin returns the index of the first element of the sequence that
+// match returns true for, along with the element.  The index is -1 if there is none.
+// Usage:
+// index, value := a.FindPlugin(func(value *ReportPlugin) bool { return value != nil })
+func (a *SequenceReportPlugin) FindPlugin(match func(*ReportPlugin) bool) (int, *ReportPlugin) {
+	for index, value := range a.Plugin {
+		if match(value) {
+			return index, value
+		}
+	}
+	return -1, nil
+}
+
+// LenPlugin returns the number of elements in the sequence.
+// Usage:
+// count := a.LenPlugin()
+func (a *SequenceReportPlugin) LenPlugin() int {
+	return len(a.Plugin)
+}
+
+// ClearPlugin removes every element from the sequence.
+// Usage:
+// a.ClearPlugin()
+func (a *SequenceReportPlugin) ClearPlugin() {
+	a.Plugin = nil
+}
+
 // Reporting Section for management of reports and their configuration.
 type Reporting struct {
 
@@ -4695,7 +5767,7 @@ type Reporting struct {
 	OutputDirectory *string `xml:"outputDirectory,omitempty"`
 
 	/* Plugins The reporting plugins to use and their configuration.*/
-	Plugins *SequencePlugin `xml:"plugins,omitempty"`
+	Plugins *SequenceReportPlugin `xml:"plugins,omitempty"`
 
 	Comment string `xml:",comment"`
 }
@@ -4753,17 +5825,17 @@ func (a *Reporting) SetOutputDirectory(value string) {
 //   if value, ok := a.GetPlugins(); ok {
 //        fmt.Println(value)
 //    }
-func (a *Reporting) GetPlugins() (returnValue SequencePlugin, exists bool) {
+func (a *Reporting) GetPlugins() (returnValue SequenceReportPlugin, exists bool) {
 	if a.Plugins != nil {
 		return *a.Plugins, true
 	}
-	return SequencePlugin{}, false
+	return SequenceReportPlugin{}, false
 }
 
 // SetPlugins will overwrite whatever value is currently set for Plugins.
 // Usage:
-// a.SetPlugins(SequencePlugin{})
-func (a *Reporting) SetPlugins(value SequencePlugin) {
+// a.SetPlugins(SequenceReportPlugin{})
+func (a *Reporting) SetPlugins(value SequenceReportPlugin) {
 	copy := value
 	a.Plugins = &copy
 
@@ -4836,17 +5908,17 @@ func (a *SequenceReportSet) SetReportSet(value []*ReportSet) {
 
 }
 
-// UpdateReportSet will update a sequence at index.  If indx is greater than the
-// length of the sequence, we add it to the end.
+// UpdateReportSet will update a sequence at index.  If index is outside of the
+// sequence, we add it to the end.
 // Usage:
 // value := ReportSet{ }
 // a.UpdateReportSet(value, 2)
 func (a *SequenceReportSet) UpdateReportSet(value *ReportSet, index int) {
-	current := a.GetReportSet()
-	if len(current) > index {
+	if index >= 0 && index < len(a.ReportSet) {
 		a.ReportSet[index] = value
+		return
 	}
-	a.ReportSet = append(current, value)
+	a.ReportSet = append(a.ReportSet, value)
 }
 
 // AddReportSet adds a new element to the sequence.  If the sequence is nil, it is created.
@@ -4857,6 +5929,61 @@ func (a *SequenceReportSet) AddReportSet(value *ReportSet) {
 	a.ReportSet = append(a.ReportSet, value)
 }
 
+// InsertReportSet adds a new element to the sequence before index.  If index is
+// past the end of the sequence, the element is added to the end.
+// Usage:
+// value := ReportSet{ }
+// a.InsertReportSet(value, 0)
+func (a *SequenceReportSet) InsertReportSet(value *ReportSet, index int) {
+	if index < 0 {
+		index = 0
+	}
+	if index >= len(a.ReportSet) {
+		a.ReportSet = append(a.ReportSet, value)
+		return
+	}
+	a.ReportSet = append(a.ReportSet[:index], append([]*ReportSet{value}, a.ReportSet[index:]...)...)
+}
+
+// RemoveReportSet removes the element at index from the sequence, and reports
+// whether there was one to remove.
+// Usage:
+// a.RemoveReportSet(2)
+func (a *SequenceReportSet) RemoveReportSet(index int) bool {
+	if index < 0 || index >= len(a.ReportSet) {
+		return false
+	}
+	a.ReportSet = append(a.ReportSet[:index], a.ReportSet[index+1:]...)
+	return true
+}
+
+// FindReportSet returns the index of the first element of the sequence that
+// match returns true for, along with the element.  The index is -1 if there is none.
+// Usage:
+// index, value := a.FindReportSet(func(value *ReportSet) bool { return value != nil })
+func (a *SequenceReportSet) FindReportSet(match func(*ReportSet) bool) (int, *ReportSet) {
+	for index, value := range a.ReportSet {
+		if match(value) {
+			return index, value
+		}
+	}
+	return -1, nil
+}
+
+// LenReportSet returns the number of elements in the sequence.
+// Usage:
+// count := a.LenReportSet()
+func (a *SequenceReportSet) LenReportSet() int {
+	return len(a.ReportSet)
+}
+
+// ClearReportSet removes every element from the sequence.
+// Usage:
+// a.ClearReportSet()
+func (a *SequenceReportSet) ClearReportSet() {
+	a.ReportSet = nil
+}
+
 // ReportPlugin The <code>&lt;plugin&gt;</code> element contains informations required for a report plugin.
 type ReportPlugin struct {
 
@@ -5100,25 +6227,80 @@ func (a *SequenceReport) SetReport(value []*string) {
 
 }
 
-// UpdateReport will update a sequence at index.  If indx is greater than the
-// length of the sequence, we add it to the end.
+// UpdateReport will update a sequence at index.  If index is outside of the
+// sequence, we add it to the end.
 // Usage:
 // value := string{ }
 // a.UpdateReport(value, 2)
 func (a *SequenceReport) UpdateReport(value *string, index int) {
-	current := a.GetReport()
-	if len(current) > index {
+	if index >= 0 && index < len(a.Report) {
 		a.Report[index] = value
+		return
 	}
-	a.Report = append(current, value)
+	a.Report = append(a.Report, value)
 }
 
 // AddReport adds a new element to the sequence.  If the sequence is nil, it is created.
 // Usage:
-// value := string{ }
-// a.AddReport(value)
-func (a *SequenceReport) AddReport(value *string) {
-	a.Report = append(a.Report, value)
+// value := string{ }
+// a.AddReport(value)
+func (a *SequenceReport) AddReport(value *string) {
+	a.Report = append(a.Report, value)
+}
+
+// InsertReport adds a new element to the sequence before index.  If index is
+// past the end of the sequence, the element is added to the end.
+// Usage:
+// value := string{ }
+// a.InsertReport(value, 0)
+func (a *SequenceReport) InsertReport(value *string, index int) {
+	if index < 0 {
+		index = 0
+	}
+	if index >= len(a.Report) {
+		a.Report = append(a.Report, value)
+		return
+	}
+	a.Report = append(a.Report[:index], append([]*string{value}, a.Report[index:]...)...)
+}
+
+// RemoveReport removes the element at index from the sequence, and reports
+// whether there was one to remove.
+// Usage:
+// a.RemoveReport(2)
+func (a *SequenceReport) RemoveReport(index int) bool {
+	if index < 0 || index >= len(a.Report) {
+		return false
+	}
+	a.Report = append(a.Report[:index], a.Report[index+1:]...)
+	return true
+}
+
+// FindReport returns the index of the first element of the sequence that
+// match returns true for, along with the element.  The index is -1 if there is none.
+// Usage:
+// index, value := a.FindReport(func(value *string) bool { return value != nil })
+func (a *SequenceReport) FindReport(match func(*string) bool) (int, *string) {
+	for index, value := range a.Report {
+		if match(value) {
+			return index, value
+		}
+	}
+	return -1, nil
+}
+
+// LenReport returns the number of elements in the sequence.
+// Usage:
+// count := a.LenReport()
+func (a *SequenceReport) LenReport() int {
+	return len(a.Report)
+}
+
+// ClearReport removes every element from the sequence.
+// Usage:
+// a.ClearReport()
+func (a *SequenceReport) ClearReport() {
+	a.Report = nil
 }
 
 // ReportSet Represents a set of reports and configuration to be used to generate them.
@@ -6318,17 +7500,17 @@ func (a *SequenceResource) SetResource(value []*Resource) {
 
 }
 
-// UpdateResource will update a sequence at index.  If indx is greater than the
-// length of the sequence, we add it to the end.
+// UpdateResource will update a sequence at index.  If index is outside of the
+// sequence, we add it to the end.
 // Usage:
 // value := Resource{ }
 // a.UpdateResource(value, 2)
 func (a *SequenceResource) UpdateResource(value *Resource, index int) {
-	current := a.GetResource()
-	if len(current) > index {
+	if index >= 0 && index < len(a.Resource) {
 		a.Resource[index] = value
+		return
 	}
-	a.Resource = append(current, value)
+	a.Resource = append(a.Resource, value)
 }
 
 // AddResource adds a new element to the sequence.  If the sequence is nil, it is created.
@@ -6339,6 +7521,61 @@ func (a *SequenceResource) AddResource(value *Resource) {
 	a.Resource = append(a.Resource, value)
 }
 
+// InsertResource adds a new element to the sequence before index.  If index is
+// past the end of the sequence, the element is added to the end.
+// Usage:
+// value := Resource{ }
+// a.InsertResource(value, 0)
+func (a *SequenceResource) InsertResource(value *Resource, index int) {
+	if index < 0 {
+		index = 0
+	}
+	if index >= len(a.Resource) {
+		a.Resource = append(a.Resource, value)
+		return
+	}
+	a.Resource = append(a.Resource[:index], append([]*Resource{value}, a.Resource[index:]...)...)
+}
+
+// RemoveResource removes the element at index from the sequence, and reports
+// whether there was one to remove.
+// Usage:
+// a.RemoveResource(2)
+func (a *SequenceResource) RemoveResource(index int) bool {
+	if index < 0 || index >= len(a.Resource) {
+		return false
+	}
+	a.Resource = append(a.Resource[:index], a.Resource[index+1:]...)
+	return true
+}
+
+// FindResource returns the index of the first element of the sequence that
+// match returns true for, along with the element.  The index is -1 if there is none.
+// Usage:
+// index, value := a.FindResource(func(value *Resource) bool { return value != nil })
+func (a *SequenceResource) FindResource(match func(*Resource) bool) (int, *Resource) {
+	for index, value := range a.Resource {
+		if match(value) {
+			return index, value
+		}
+	}
+	return -1, nil
+}
+
+// LenResource returns the number of elements in the sequence.
+// Usage:
+// count := a.LenResource()
+func (a *SequenceResource) LenResource() int {
+	return len(a.Resource)
+}
+
+// ClearResource removes every element from the sequence.
+// Usage:
+// a.ClearResource()
+func (a *SequenceResource) ClearResource() {
+	a.Resource = nil
+}
+
 // SequenceTestResource contains the subelements for iterables in XML
 type SequenceTestResource struct {
 	Comment string `xml:",comment"`
@@ -6387,17 +7624,17 @@ func (a *SequenceTestResource) SetTestResource(value []*Resource) {
 
 }
 
-// UpdateTestResource will update a sequence at index.  If indx is greater than the
-// length of the sequence, we add it to the end.
+// UpdateTestResource will update a sequence at index.  If index is outside of the
+// sequence, we add it to the end.
 // Usage:
 // value := Resource{ }
 // a.UpdateTestResource(value, 2)
 func (a *SequenceTestResource) UpdateTestResource(value *Resource, index int) {
-	current := a.GetTestResource()
-	if len(current) > index {
+	if index >= 0 && index < len(a.TestResource) {
 		a.TestResource[index] = value
+		return
 	}
-	a.TestResource = append(current, value)
+	a.TestResource = append(a.TestResource, value)
 }
 
 // AddTestResource adds a new element to the sequence.  If the sequence is nil, it is created.
@@ -6408,6 +7645,61 @@ func (a *SequenceTestResource) AddTestResource(value *Resource) {
 	a.TestResource = append(a.TestResource, value)
 }
 
+// InsertTestResource adds a new element to the sequence before index.  If index is
+// past the end of the sequence, the element is added to the end.
+// Usage:
+// value := Resource{ }
+// a.InsertTestResource(value, 0)
+func (a *SequenceTestResource) InsertTestResource(value *Resource, index int) {
+	if index < 0 {
+		index = 0
+	}
+	if index >= len(a.TestResource) {
+		a.TestResource = append(a.TestResource, value)
+		return
+	}
+	a.TestResource = append(a.TestResource[:index], append([]*Resource{value}, a.TestResource[index:]...)...)
+}
+
+// RemoveTestResource removes the element at index from the sequence, and reports
+// whether there was one to remove.
+// Usage:
+// a.RemoveTestResource(2)
+func (a *SequenceTestResource) RemoveTestResource(index int) bool {
+	if index < 0 || index >= len(a.TestResource) {
+		return false
+	}
+	a.TestResource = append(a.TestResource[:index], a.TestResource[index+1:]...)
+	return true
+}
+
+// FindTestResource returns the index of the first element of the sequence that
+// match returns true for, along with the element.  The index is -1 if there is none.
+// Usage:
+// index, value := a.FindTestResource(func(value *Resource) bool { return value != nil })
+func (a *SequenceTestResource) FindTestResource(match func(*Resource) bool) (int, *Resource) {
+	for index, value := range a.TestResource {
+		if match(value) {
+			return index, value
+		}
+	}
+	return -1, nil
+}
+
+// LenTestResource returns the number of elements in the sequence.
+// Usage:
+// count := a.LenTestResource()
+func (a *SequenceTestResource) LenTestResource() int {
+	return len(a.TestResource)
+}
+
+// ClearTestResource removes every element from the sequence.
+// Usage:
+// a.ClearTestResource()
+func (a *SequenceTestResource) ClearTestResource() {
+	a.TestResource = nil
+}
+
 // SequenceFilter contains the subelements for iterables in XML
 type SequenceFilter struct {
 	Comment string `xml:",comment"`
@@ -6456,17 +7748,17 @@ func (a *SequenceFilter) SetFilter(value []*string) {
 
 }
 
-// UpdateFilter will update a sequence at index.  If indx is greater than the
-// length of the sequence, we add it to the end.
+// UpdateFilter will update a sequence at index.  If index is outside of the
+// sequence, we add it to the end.
 // Usage:
 // value := string{ }
 // a.UpdateFilter(value, 2)
 func (a *SequenceFilter) UpdateFilter(value *string, index int) {
-	current := a.GetFilter()
-	if len(current) > index {
+	if index >= 0 && index < len(a.Filter) {
 		a.Filter[index] = value
+		return
 	}
-	a.Filter = append(current, value)
+	a.Filter = append(a.Filter, value)
 }
 
 // AddFilter adds a new element to the sequence.  If the sequence is nil, it is created.
@@ -6477,6 +7769,185 @@ func (a *SequenceFilter) AddFilter(value *string) {
 	a.Filter = append(a.Filter, value)
 }
 
+// InsertFilter adds a new element to the sequence before index.  If index is
+// past the end of the sequence, the element is added to the end.
+// Usage:
+// value := string{ }
+// a.InsertFilter(value, 0)
+func (a *SequenceFilter) InsertFilter(value *string, index int) {
+	if index < 0 {
+		index = 0
+	}
+	if index >= len(a.Filter) {
+		a.Filter = append(a.Filter, value)
+		return
+	}
+	a.Filter = append(a.Filter[:index], append([]*string{value}, a.Filter[index:]...)...)
+}
+
+// RemoveFilter removes the element at index from the sequence, and reports
+// whether there was one to remove.
+// Usage:
+// a.RemoveFilter(2)
+func (a *SequenceFilter) RemoveFilter(index int) bool {
+	if index < 0 || index >= len(a.Filter) {
+		return false
+	}
+	a.Filter = append(a.Filter[:index], a.Filter[index+1:]...)
+	return true
+}
+
+// FindFilter returns the index of the first element of the sequence that
+// match returns true for, along with the element.  The index is -1 if there is none.
+// Usage:
+// index, value := a.FindFilter(func(value *string) bool { return value != nil })
+func (a *SequenceFilter) FindFilter(match func(*string) bool) (int, *string) {
+	for index, value := range a.Filter {
+		if match(value) {
+			return index, value
+		}
+	}
+	return -1, nil
+}
+
+// LenFilter returns the number of elements in the sequence.
+// Usage:
+// count := a.LenFilter()
+func (a *SequenceFilter) LenFilter() int {
+	return len(a.Filter)
+}
+
+// ClearFilter removes every element from the sequence.
+// Usage:
+// a.ClearFilter()
+func (a *SequenceFilter) ClearFilter() {
+	a.Filter = nil
+}
+
+// SequencePlugin contains the subelements for iterables in XML
+type SequencePlugin struct {
+	Comment string `xml:",comment"`
+
+	Plugin []*Plugin `xml:"plugin,omitempty"`
+}
+
+// GetComment Gets the value of Comment and returns it.
+// If the value does not exist, then the default empty value is returned
+// and exists is set to false
+// Usage:
+//   if value, ok := a.GetComment(); ok {
+//        fmt.Println(value)
+//    }
+func (a *SequencePlugin) GetComment() (returnValue string, exists bool) {
+	return a.Comment, false
+}
+
+// SetComment will overwrite whatever value is currently set for Comment.
+// Usage:
+// a.SetComment()
+func (a *SequencePlugin) SetComment(value string) {
+	a.Comment = value
+
+}
+
+// GetPlugin Gets the value of Plugin and returns it.
+// If the value does not exist, then the default empty value is returned
+// and exists is set to false
+// Usage:
+//   if value, ok := a.GetPlugin(); ok {
+//        fmt.Println(value)
+//    }
+func (a *SequencePlugin) GetPlugin() (returnValue []*Plugin) {
+	if a.Plugin != nil {
+		return a.Plugin
+	}
+	return []*Plugin{}
+}
+
+// SetPlugin will overwrite whatever value is currently set for Plugin.
+// Usage:
+// a.SetPlugin(Plugin{})
+func (a *SequencePlugin) SetPlugin(value []*Plugin) {
+	a.Plugin = value
+
+}
+
+// UpdatePlugin will update a sequence at index.  If index is outside of the
+// sequence, we add it to the end.
+// Usage:
+// value := Plugin{ }
+// a.UpdatePlugin(value, 2)
+func (a *SequencePlugin) UpdatePlugin(value *Plugin, index int) {
+	if index >= 0 && index < len(a.Plugin) {
+		a.Plugin[index] = value
+		return
+	}
+	a.Plugin = append(a.Plugin, value)
+}
+
+// AddPlugin adds a new element to the sequence.  If the sequence is nil, it is created.
+// Usage:
+// value := Plugin{ }
+// a.AddPlugin(value)
+func (a *SequencePlugin) AddPlugin(value *Plugin) {
+	a.Plugin = append(a.Plugin, value)
+}
+
+// InsertPlugin adds a new element to the sequence before index.  If index is
+// past the end of the sequence, the element is added to the end.
+// Usage:
+// value := Plugin{ }
+// a.InsertPlugin(value, 0)
+func (a *SequencePlugin) InsertPlugin(value *Plugin, index int) {
+	if index < 0 {
+		index = 0
+	}
+	if index >= len(a.Plugin) {
+		a.Plugin = append(a.Plugin, value)
+		return
+	}
+	a.Plugin = append(a.Plugin[:index], append([]*Plugin{value}, a.Plugin[index:]...)...)
+}
+
+// RemovePlugin removes the element at index from the sequence, and reports
+// whether there was one to remove.
+// Usage:
+// a.RemovePlugin(2)
+func (a *SequencePlugin) RemovePlugin(index int) bool {
+	if index < 0 || index >= len(a.Plugin) {
+		return false
+	}
+	a.Plugin = append(a.Plugin[:index], a.Plugin[index+1:]...)
+	return true
+}
+
+// FindPlugin returns the index of the first element of the sequence that
+// match returns true for, along with the element.  The index is -1 if there is none.
+// Usage:
+// index, value := a.FindPlugin(func(value *Plugin) bool { return value != nil })
+func (a *SequencePlugin) FindPlugin(match func(*Plugin) bool) (int, *Plugin) {
+	for index, value := range a.Plugin {
+		if match(value) {
+			return index, value
+		}
+	}
+	return -1, nil
+}
+
+// LenPlugin returns the number of elements in the sequence.
+// Usage:
+// count := a.LenPlugin()
+func (a *SequencePlugin) LenPlugin() int {
+	return len(a.Plugin)
+}
+
+// ClearPlugin removes every element from the sequence.
+// Usage:
+// a.ClearPlugin()
+func (a *SequencePlugin) ClearPlugin() {
+	a.Plugin = nil
+}
+
 // BuildBase Generic informations for a build.
 type BuildBase struct {
 
@@ -6772,17 +8243,17 @@ func (a *SequenceExecution) SetExecution(value []*PluginExecution) {
 
 }
 
-// UpdateExecution will update a sequence at index.  If indx is greater than the
-// length of the sequence, we add it to the end.
+// UpdateExecution will update a sequence at index.  If index is outside of the
+// sequence, we add it to the end.
 // Usage:
 // value := PluginExecution{ }
 // a.UpdateExecution(value, 2)
 func (a *SequenceExecution) UpdateExecution(value *PluginExecution, index int) {
-	current := a.GetExecution()
-	if len(current) > index {
+	if index >= 0 && index < len(a.Execution) {
 		a.Execution[index] = value
+		return
 	}
-	a.Execution = append(current, value)
+	a.Execution = append(a.Execution, value)
 }
 
 // AddExecution adds a new element to the sequence.  If the sequence is nil, it is created.
@@ -6793,6 +8264,61 @@ func (a *SequenceExecution) AddExecution(value *PluginExecution) {
 	a.Execution = append(a.Execution, value)
 }
 
+// InsertExecution adds a new element to the sequence before index.  If index is
+// past the end of the sequence, the element is added to the end.
+// Usage:
+// value := PluginExecution{ }
+// a.InsertExecution(value, 0)
+func (a *SequenceExecution) InsertExecution(value *PluginExecution, index int) {
+	if index < 0 {
+		index = 0
+	}
+	if index >= len(a.Execution) {
+		a.Execution = append(a.Execution, value)
+		return
+	}
+	a.Execution = append(a.Execution[:index], append([]*PluginExecution{value}, a.Execution[index:]...)...)
+}
+
+// RemoveExecution removes the element at index from the sequence, and reports
+// whether there was one to remove.
+// Usage:
+// a.RemoveExecution(2)
+func (a *SequenceExecution) RemoveExecution(index int) bool {
+	if index < 0 || index >= len(a.Execution) {
+		return false
+	}
+	a.Execution = append(a.Execution[:index], a.Execution[index+1:]...)
+	return true
+}
+
+// FindExecution returns the index of the first element of the sequence that
+// match returns true for, along with the element.  The index is -1 if there is none.
+// Usage:
+// index, value := a.FindExecution(func(value *PluginExecution) bool { return value != nil })
+func (a *SequenceExecution) FindExecution(match func(*PluginExecution) bool) (int, *PluginExecution) {
+	for index, value := range a.Execution {
+		if match(value) {
+			return index, value
+		}
+	}
+	return -1, nil
+}
+
+// LenExecution returns the number of elements in the sequence.
+// Usage:
+// count := a.LenExecution()
+func (a *SequenceExecution) LenExecution() int {
+	return len(a.Execution)
+}
+
+// ClearExecution removes every element from the sequence.
+// Usage:
+// a.ClearExecution()
+func (a *SequenceExecution) ClearExecution() {
+	a.Execution = nil
+}
+
 // Plugin The <code>&lt;plugin&gt;</code> element contains informations required for a plugin.
 type Plugin struct {
 
@@ -7118,17 +8644,17 @@ func (a *SequenceGoal) SetGoal(value []*string) {
 
 }
 
-// UpdateGoal will update a sequence at index.  If indx is greater than the
-// length of the sequence, we add it to the end.
+// UpdateGoal will update a sequence at index.  If index is outside of the
+// sequence, we add it to the end.
 // Usage:
 // value := string{ }
 // a.UpdateGoal(value, 2)
 func (a *SequenceGoal) UpdateGoal(value *string, index int) {
-	current := a.GetGoal()
-	if len(current) > index {
+	if index >= 0 && index < len(a.Goal) {
 		a.Goal[index] = value
+		return
 	}
-	a.Goal = append(current, value)
+	a.Goal = append(a.Goal, value)
 }
 
 // AddGoal adds a new element to the sequence.  If the sequence is nil, it is created.
@@ -7139,6 +8665,61 @@ func (a *SequenceGoal) AddGoal(value *string) {
 	a.Goal = append(a.Goal, value)
 }
 
+// InsertGoal adds a new element to the sequence before index.  If index is
+// past the end of the sequence, the element is added to the end.
+// Usage:
+// value := string{ }
+// a.InsertGoal(value, 0)
+func (a *SequenceGoal) InsertGoal(value *string, index int) {
+	if index < 0 {
+		index = 0
+	}
+	if index >= len(a.Goal) {
+		a.Goal = append(a.Goal, value)
+		return
+	}
+	a.Goal = append(a.Goal[:index], append([]*string{value}, a.Goal[index:]...)...)
+}
+
+// RemoveGoal removes the element at index from the sequence, and reports
+// whether there was one to remove.
+// Usage:
+// a.RemoveGoal(2)
+func (a *SequenceGoal) RemoveGoal(index int) bool {
+	if index < 0 || index >= len(a.Goal) {
+		return false
+	}
+	a.Goal = append(a.Goal[:index], a.Goal[index+1:]...)
+	return true
+}
+
+// FindGoal returns the index of the first element of the sequence that
+// match returns true for, along with the element.  The index is -1 if there is none.
+// Usage:
+// index, value := a.FindGoal(func(value *string) bool { return value != nil })
+func (a *SequenceGoal) FindGoal(match func(*string) bool) (int, *string) {
+	for index, value := range a.Goal {
+		if match(value) {
+			return index, value
+		}
+	}
+	return -1, nil
+}
+
+// LenGoal returns the number of elements in the sequence.
+// Usage:
+// count := a.LenGoal()
+func (a *SequenceGoal) LenGoal() int {
+	return len(a.Goal)
+}
+
+// ClearGoal removes every element from the sequence.
+// Usage:
+// a.ClearGoal()
+func (a *SequenceGoal) ClearGoal() {
+	a.Goal = nil
+}
+
 // PluginExecution The <code>&lt;execution&gt;</code> element contains informations required for the
 //        execution of a plugin.
 type PluginExecution struct {
@@ -7358,17 +8939,17 @@ func (a *SequenceInclude) SetInclude(value []*string) {
 
 }
 
-// UpdateInclude will update a sequence at index.  If indx is greater than the
-// length of the sequence, we add it to the end.
+// UpdateInclude will update a sequence at index.  If index is outside of the
+// sequence, we add it to the end.
 // Usage:
 // value := string{ }
 // a.UpdateInclude(value, 2)
 func (a *SequenceInclude) UpdateInclude(value *string, index int) {
-	current := a.GetInclude()
-	if len(current) > index {
+	if index >= 0 && index < len(a.Include) {
 		a.Include[index] = value
+		return
 	}
-	a.Include = append(current, value)
+	a.Include = append(a.Include, value)
 }
 
 // AddInclude adds a new element to the sequence.  If the sequence is nil, it is created.
@@ -7379,6 +8960,61 @@ func (a *SequenceInclude) AddInclude(value *string) {
 	a.Include = append(a.Include, value)
 }
 
+// InsertInclude adds a new element to the sequence before index.  If index is
+// past the end of the sequence, the element is added to the end.
+// Usage:
+// value := string{ }
+// a.InsertInclude(value, 0)
+func (a *SequenceInclude) InsertInclude(value *string, index int) {
+	if index < 0 {
+		index = 0
+	}
+	if index >= len(a.Include) {
+		a.Include = append(a.Include, value)
+		return
+	}
+	a.Include = append(a.Include[:index], append([]*string{value}, a.Include[index:]...)...)
+}
+
+// RemoveInclude removes the element at index from the sequence, and reports
+// whether there was one to remove.
+// Usage:
+// a.RemoveInclude(2)
+func (a *SequenceInclude) RemoveInclude(index int) bool {
+	if index < 0 || index >= len(a.Include) {
+		return false
+	}
+	a.Include = append(a.Include[:index], a.Include[index+1:]...)
+	return true
+}
+
+// FindInclude returns the index of the first element of the sequence that
+// match returns true for, along with the element.  The index is -1 if there is none.
+// Usage:
+// index, value := a.FindInclude(func(value *string) bool { return value != nil })
+func (a *SequenceInclude) FindInclude(match func(*string) bool) (int, *string) {
+	for index, value := range a.Include {
+		if match(value) {
+			return index, value
+		}
+	}
+	return -1, nil
+}
+
+// LenInclude returns the number of elements in the sequence.
+// Usage:
+// count := a.LenInclude()
+func (a *SequenceInclude) LenInclude() int {
+	return len(a.Include)
+}
+
+// ClearInclude removes every element from the sequence.
+// Usage:
+// a.ClearInclude()
+func (a *SequenceInclude) ClearInclude() {
+	a.Include = nil
+}
+
 // SequenceExclude contains the subelements for iterables in XML
 type SequenceExclude struct {
 	Comment string `xml:",comment"`
@@ -7427,17 +9063,17 @@ func (a *SequenceExclude) SetExclude(value []*string) {
 
 }
 
-// UpdateExclude will update a sequence at index.  If indx is greater than the
-// length of the sequence, we add it to the end.
+// UpdateExclude will update a sequence at index.  If index is outside of the
+// sequence, we add it to the end.
 // Usage:
 // value := string{ }
 // a.UpdateExclude(value, 2)
 func (a *SequenceExclude) UpdateExclude(value *string, index int) {
-	current := a.GetExclude()
-	if len(current) > index {
+	if index >= 0 && index < len(a.Exclude) {
 		a.Exclude[index] = value
+		return
 	}
-	a.Exclude = append(current, value)
+	a.Exclude = append(a.Exclude, value)
 }
 
 // AddExclude adds a new element to the sequence.  If the sequence is nil, it is created.
@@ -7448,6 +9084,61 @@ func (a *SequenceExclude) AddExclude(value *string) {
 	a.Exclude = append(a.Exclude, value)
 }
 
+// InsertExclude adds a new element to the sequence before index.  If index is
+// past the end of the sequence, the element is added to the end.
+// Usage:
+// value := string{ }
+// a.InsertExclude(value, 0)
+func (a *SequenceExclude) InsertExclude(value *string, index int) {
+	if index < 0 {
+		index = 0
+	}
+	if index >= len(a.Exclude) {
+		a.Exclude = append(a.Exclude, value)
+		return
+	}
+	a.Exclude = append(a.Exclude[:index], append([]*string{value}, a.Exclude[index:]...)...)
+}
+
+// RemoveExclude removes the element at index from the sequence, and reports
+// whether there was one to remove.
+// Usage:
+// a.RemoveExclude(2)
+func (a *SequenceExclude) RemoveExclude(index int) bool {
+	if index < 0 || index >= len(a.Exclude) {
+		return false
+	}
+	a.Exclude = append(a.Exclude[:index], a.Exclude[index+1:]...)
+	return true
+}
+
+// FindExclude returns the index of the first element of the sequence that
+// match returns true for, along with the element.  The index is -1 if there is none.
+// Usage:
+// index, value := a.FindExclude(func(value *string) bool { return value != nil })
+func (a *SequenceExclude) FindExclude(match func(*string) bool) (int, *string) {
+	for index, value := range a.Exclude {
+		if match(value) {
+			return index, value
+		}
+	}
+	return -1, nil
+}
+
+// LenExclude returns the number of elements in the sequence.
+// Usage:
+// count := a.LenExclude()
+func (a *SequenceExclude) LenExclude() int {
+	return len(a.Exclude)
+}
+
+// ClearExclude removes every element from the sequence.
+// Usage:
+// a.ClearExclude()
+func (a *SequenceExclude) ClearExclude() {
+	a.Exclude = nil
+}
+
 // Resource This element describes all of the classpath resources associated with a project
 //        or unit tests.
 type Resource struct {
@@ -7770,17 +9461,17 @@ func (a *SequenceExtension) SetExtension(value []*Extension) {
 
 }
 
-// UpdateExtension will update a sequence at index.  If indx is greater than the
-// length of the sequence, we add it to the end.
+// UpdateExtension will update a sequence at index.  If index is outside of the
+// sequence, we add it to the end.
 // Usage:
 // value := Extension{ }
 // a.UpdateExtension(value, 2)
 func (a *SequenceExtension) UpdateExtension(value *Extension, index int) {
-	current := a.GetExtension()
-	if len(current) > index {
+	if index >= 0 && index < len(a.Extension) {
 		a.Extension[index] = value
+		return
 	}
-	a.Extension = append(current, value)
+	a.Extension = append(a.Extension, value)
 }
 
 // AddExtension adds a new element to the sequence.  If the sequence is nil, it is created.
@@ -7791,6 +9482,61 @@ func (a *SequenceExtension) AddExtension(value *Extension) {
 	a.Extension = append(a.Extension, value)
 }
 
+// InsertExtension adds a new element to the sequence before index.  If index is
+// past the end of the sequence, the element is added to the end.
+// Usage:
+// value := Extension{ }
+// a.InsertExtension(value, 0)
+func (a *SequenceExtension) InsertExtension(value *Extension, index int) {
+	if index < 0 {
+		index = 0
+	}
+	if index >= len(a.Extension) {
+		a.Extension = append(a.Extension, value)
+		return
+	}
+	a.Extension = append(a.Extension[:index], append([]*Extension{value}, a.Extension[index:]...)...)
+}
+
+// RemoveExtension removes the element at index from the sequence, and reports
+// whether there was one to remove.
+// Usage:
+// a.RemoveExtension(2)
+func (a *SequenceExtension) RemoveExtension(index int) bool {
+	if index < 0 || index >= len(a.Extension) {
+		return false
+	}
+	a.Extension = append(a.Extension[:index], a.Extension[index+1:]...)
+	return true
+}
+
+// FindExtension returns the index of the first element of the sequence that
+// match returns true for, along with the element.  The index is -1 if there is none.
+// Usage:
+// index, value := a.FindExtension(func(value *Extension) bool { return value != nil })
+func (a *SequenceExtension) FindExtension(match func(*Extension) bool) (int, *Extension) {
+	for index, value := range a.Extension {
+		if match(value) {
+			return index, value
+		}
+	}
+	return -1, nil
+}
+
+// LenExtension returns the number of elements in the sequence.
+// Usage:
+// count := a.LenExtension()
+func (a *SequenceExtension) LenExtension() int {
+	return len(a.Extension)
+}
+
+// ClearExtension removes every element from the sequence.
+// Usage:
+// a.ClearExtension()
+func (a *SequenceExtension) ClearExtension() {
+	a.Extension = nil
+}
+
 // Build The <code>&lt;build&gt;</code> element contains informations required to build the project.
 //        Default values are defined in Super POM.
 type Build struct {
@@ -0,0 +1,267 @@
+package pom
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// expressionPattern matches a single ${...} expression
+var expressionPattern = regexp.MustCompile(`\$\{([^${}]+)\}`)
+
+// superPOMDefaults are the values Maven's super POM gives a project if it does not set them itself
+var superPOMDefaults = map[string]string{
+	"project.packaging":                   "jar",
+	"project.build.directory":             "${project.basedir}/target",
+	"project.build.outputDirectory":       "${project.build.directory}/classes",
+	"project.build.testOutputDirectory":   "${project.build.directory}/test-classes",
+	"project.build.sourceDirectory":       "${project.basedir}/src/main/java",
+	"project.build.scriptSourceDirectory": "${project.basedir}/src/main/scripts",
+	"project.build.testSourceDirectory":   "${project.basedir}/src/test/java",
+	"project.build.finalName":             "${project.artifactId}-${project.version}",
+	"project.reporting.outputDirectory":   "${project.build.directory}/site",
+}
+
+// Interpolator resolves ${...} expressions the way Maven does.  Each expression
+// is looked up, in order, as:
+//   - basedir, project.basedir and project.baseUri, from BaseDir
+//   - project.* (or pom.*), a path through Model such as project.parent.groupId
+//   - SystemProperties, like -D on the mvn command line
+//   - the <properties> of Model, where the last one wins if a property is written twice
+//   - env.*, from Environment
+type Interpolator struct {
+	Model            Model
+	BaseDir          string
+	SystemProperties map[string]string
+	Environment      map[string]string
+}
+
+// NewInterpolator returns an Interpolator for model, which lives in baseDir.
+// The environment is taken from the running process.
+func NewInterpolator(model Model, baseDir string) *Interpolator {
+	environment := make(map[string]string)
+	for _, variable := range os.Environ() {
+		if i := strings.Index(variable, "="); i > 0 {
+			environment[variable[:i]] = variable[i+1:]
+		}
+	}
+	return &Interpolator{
+		Model:            model,
+		BaseDir:          baseDir,
+		SystemProperties: make(map[string]string),
+		Environment:      environment,
+	}
+}
+
+// InterpolationError is returned when some expressions could not be resolved.
+// Expressions that could not be resolved are left as they are.
+type InterpolationError struct {
+	// Unresolved are the keys that had no value
+	Unresolved []string
+	// Recursive are the keys whose value ended up referring back to themselves
+	Recursive []string
+}
+
+func (e *InterpolationError) Error() string {
+	problems := make([]string, 0, 2)
+	if len(e.Unresolved) > 0 {
+		problems = append(problems, "could not resolve ${"+strings.Join(e.Unresolved, "}, ${")+"}")
+	}
+	if len(e.Recursive) > 0 {
+		problems = append(problems, "recursive reference to ${"+strings.Join(e.Recursive, "}, ${")+"}")
+	}
+	return "pom: " + strings.Join(problems, "; ")
+}
+
+// add records a problem with key, only once
+func (e *InterpolationError) add(list *[]string, key string) {
+	for _, existing := range *list {
+		if existing == key {
+			return
+		}
+	}
+	*list = append(*list, key)
+}
+
+func (e *InterpolationError) err() error {
+	if len(e.Unresolved) == 0 && len(e.Recursive) == 0 {
+		return nil
+	}
+	return e
+}
+
+// Resolve replaces every ${...} expression in value.
+// If anything could not be resolved, an *InterpolationError is returned alongside the result.
+func (i *Interpolator) Resolve(value string) (string, error) {
+	problems := &InterpolationError{}
+	result := i.resolve(value, nil, problems, nil)
+	return result, problems.err()
+}
+
+// InterpolateModel returns a copy of model with every expression in it resolved,
+// including those inside of plugin configuration.  model itself is not changed.
+// Expressions are resolved against model, in place of the Interpolator's own Model.
+func (i *Interpolator) InterpolateModel(model Model) (Model, error) {
+	result := deepCopy(model).(Model)
+	problems := &InterpolationError{}
+	resolver := *i
+	resolver.Model = model
+	resolver.interpolateValue(reflect.ValueOf(&result).Elem(), problems)
+	return result, problems.err()
+}
+
+// interpolateValue walks through every string in v and resolves it in place
+func (i *Interpolator) interpolateValue(v reflect.Value, problems *InterpolationError) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if !v.IsNil() {
+			i.interpolateValue(v.Elem(), problems)
+		}
+	case reflect.Slice:
+		for j := 0; j < v.Len(); j++ {
+			i.interpolateValue(v.Index(j), problems)
+		}
+	case reflect.String:
+		v.SetString(i.resolve(v.String(), nil, problems, nil))
+	case reflect.Struct:
+		if v.Type() == reflect.TypeOf(xml.Name{}) || v.Type() == reflect.TypeOf(xml.Attr{}) {
+			return
+		}
+		if inner, ok := v.Addr().Interface().(*XMLInner); ok {
+			// Values going into raw XML need escaping
+			inner.InnerXML = i.resolve(inner.InnerXML, nil, problems, escapeText)
+			return
+		}
+		for j := 0; j < v.NumField(); j++ {
+			if v.Type().Field(j).Name == "Comment" || !v.Field(j).CanSet() {
+				continue
+			}
+			i.interpolateValue(v.Field(j), problems)
+		}
+	}
+}
+
+// resolve replaces the expressions in value.  stack is the keys we are
+// already in the middle of resolving, and is used to spot recursion.
+func (i *Interpolator) resolve(value string, stack []string, problems *InterpolationError, escape func(string) string) string {
+	return expressionPattern.ReplaceAllStringFunc(value, func(expression string) string {
+		key := expression[2 : len(expression)-1]
+		for _, resolving := range stack {
+			if resolving == key {
+				problems.add(&problems.Recursive, key)
+				return expression
+			}
+		}
+		raw, ok := i.lookup(key)
+		if !ok {
+			problems.add(&problems.Unresolved, key)
+			return expression
+		}
+		resolved := i.resolve(raw, append(append([]string{}, stack...), key), problems, nil)
+		if escape != nil {
+			return escape(resolved)
+		}
+		return resolved
+	})
+}
+
+// lookup finds the raw value of key, which may itself contain expressions
+func (i *Interpolator) lookup(key string) (string, bool) {
+	switch key {
+	case "basedir", "project.basedir", "pom.basedir":
+		return i.BaseDir, i.BaseDir != ""
+	case "project.baseUri", "pom.baseUri":
+		if i.BaseDir == "" {
+			return "", false
+		}
+		return "file://" + filepath.ToSlash(i.BaseDir) + "/", true
+	}
+	if strings.HasPrefix(key, "pom.") {
+		key = "project." + strings.TrimPrefix(key, "pom.")
+	}
+	if strings.HasPrefix(key, "project.") {
+		if value, ok := i.modelValue(key); ok {
+			return value, true
+		}
+	}
+	if value, ok := i.SystemProperties[key]; ok {
+		return value, true
+	}
+	if value, ok := i.Model.Properties.Get(key); ok {
+		return value, true
+	}
+	if strings.HasPrefix(key, "env.") {
+		value, ok := i.Environment[strings.TrimPrefix(key, "env.")]
+		return value, ok
+	}
+	return "", false
+}
+
+// modelValue follows a path like project.parent.groupId through the model,
+// using the XML element names of each field
+func (i *Interpolator) modelValue(key string) (string, bool) {
+	path := strings.Split(strings.TrimPrefix(key, "project."), ".")
+	current := reflect.ValueOf(i.Model)
+	for n, name := range path {
+		for current.Kind() == reflect.Ptr {
+			if current.IsNil() {
+				return i.modelDefault(key)
+			}
+			current = current.Elem()
+		}
+		if properties, ok := current.Interface().(XMLProperties); ok {
+			// Property names can have dots in them, so the rest of the path is the name
+			return properties.Get(strings.Join(path[n:], "."))
+		}
+		if current.Kind() != reflect.Struct {
+			return "", false
+		}
+		field, ok := fieldByElementName(current, name)
+		if !ok {
+			return "", false
+		}
+		current = field
+	}
+	for current.Kind() == reflect.Ptr {
+		if current.IsNil() {
+			return i.modelDefault(key)
+		}
+		current = current.Elem()
+	}
+	if current.Kind() != reflect.String {
+		return "", false
+	}
+	return current.String(), true
+}
+
+// modelDefault is used for model values the project does not set itself.
+// groupId and version come from the parent, and some others from the super POM.
+func (i *Interpolator) modelDefault(key string) (string, bool) {
+	if i.Model.Parent != nil {
+		switch key {
+		case "project.groupId":
+			return i.Model.Parent.GetGroupID()
+		case "project.version":
+			return i.Model.Parent.GetVersion()
+		}
+	}
+	value, ok := superPOMDefaults[key]
+	return value, ok
+}
+
+// fieldByElementName finds the field of a struct that is marshalled as the element or attribute name
+func fieldByElementName(v reflect.Value, name string) (reflect.Value, bool) {
+	for j := 0; j < v.NumField(); j++ {
+		tag := v.Type().Field(j).Tag.Get("xml")
+		if i := strings.Index(tag, ","); i >= 0 {
+			tag = tag[:i]
+		}
+		if tag == name {
+			return v.Field(j), true
+		}
+	}
+	return reflect.Value{}, false
+}
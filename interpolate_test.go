@@ -0,0 +1,107 @@
+package pom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInterpolatorResolve(t *testing.T) {
+	a := assert.New(t)
+	pom, err := Unmarshal([]byte(examplePom))
+	a.NoError(err, "Error unmarshalling test data")
+	i := NewInterpolator(pom, "/src/msods")
+	i.Environment = map[string]string{"HOME": "/home/maven"}
+
+	value, err := i.Resolve("${wsdl.dir}/DirectorySync.xsd")
+	a.NoError(err)
+	a.Equal("/src/msods/src/main/resources/wsdl-modified/DirectorySync.xsd", value)
+
+	value, err = i.Resolve("${project.groupId}:${project.artifactId}:${pom.version}")
+	a.NoError(err)
+	a.Equal("com.microsoft.msods:msods-sync-client:0.4.22-SNAPSHOT", value)
+
+	value, err = i.Resolve("${project.build.finalName}.jar in ${env.HOME}")
+	a.NoError(err)
+	a.Equal("msods-sync-client-0.4.22-SNAPSHOT.jar in /home/maven", value)
+
+	value, err = i.Resolve("${project.scm.tag}")
+	a.NoError(err)
+	a.Equal("HEAD", value)
+
+	// System properties win over the model's properties
+	i.SystemProperties["wsdl.dir.name"] = "wsdl"
+	value, err = i.Resolve("${wsdl.dir}")
+	a.NoError(err)
+	a.Equal("/src/msods/src/main/resources/wsdl", value)
+}
+
+func TestInterpolatorProblems(t *testing.T) {
+	a := assert.New(t)
+	pom, err := Unmarshal([]byte(`<project>
+    <parent>
+        <groupId>com.example</groupId>
+        <version>1.0</version>
+    </parent>
+    <properties>
+        <a>${b}</a>
+        <b>${a}</b>
+    </properties>
+</project>`))
+	a.NoError(err, "Error unmarshalling test data")
+	i := NewInterpolator(pom, "")
+
+	value, err := i.Resolve("${project.parent.groupId} ${project.groupId} ${project.version}")
+	a.NoError(err)
+	a.Equal("com.example com.example 1.0", value, "Values should fall back to the parent")
+
+	value, err = i.Resolve("${a} ${missing}")
+	a.Equal("${a} ${missing}", value, "Unresolved expressions should be left alone")
+	if a.IsType(&InterpolationError{}, err) {
+		a.Equal([]string{"missing"}, err.(*InterpolationError).Unresolved)
+		a.Equal([]string{"a"}, err.(*InterpolationError).Recursive)
+	}
+}
+
+func TestInterpolateModel(t *testing.T) {
+	a := assert.New(t)
+	pom, err := Unmarshal([]byte(examplePom))
+	a.NoError(err, "Error unmarshalling test data")
+	i := NewInterpolator(pom, "/src/msods")
+
+	interpolated, err := i.InterpolateModel(pom)
+	a.NoError(err)
+	conf := interpolated.Build.Plugins.Plugin[5].Configuration.InnerXML
+	a.Contains(conf, "<excludeFilterFile>/src/msods/findbugs-exclude.xml</excludeFilterFile>")
+	a.Equal("/src/msods/src/main/resources/wsdl-modified", interpolated.Properties.Elements[0].Value)
+	a.Equal("${project.basedir}/src/main/resources/${wsdl.dir.name}", pom.Properties.Elements[0].Value, "The original model was changed")
+}
+
+func TestInterpolateOtherModel(t *testing.T) {
+	a := assert.New(t)
+	first, err := Unmarshal([]byte(`<project>
+    <artifactId>first</artifactId>
+    <properties>
+        <java.version>8</java.version>
+    </properties>
+</project>`))
+	a.NoError(err)
+	second, err := Unmarshal([]byte(`<project>
+    <artifactId>second</artifactId>
+    <name>${project.artifactId} on ${java.version}</name>
+    <properties>
+        <java.version>8</java.version>
+        <java.version>11</java.version>
+    </properties>
+</project>`))
+	a.NoError(err)
+
+	// The model given is the one resolved against, and its last property wins, the same as Get
+	interpolated, err := NewInterpolator(first, "").InterpolateModel(second)
+	a.NoError(err)
+	a.Equal("second on 11", *interpolated.Name)
+
+	value, err := NewInterpolator(second, "").Resolve("${project.properties.java.version}")
+	a.NoError(err)
+	a.Equal("11", value)
+}
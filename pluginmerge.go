@@ -0,0 +1,247 @@
+package pom
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+// MergeConfiguration merges two plugin configurations the way Maven does,
+// returning a new configuration.  dominant wins wherever both set a value:
+//   - elements only recessive has are added to dominant
+//   - elements with the same name are merged in pairs, in the order they appear
+//   - an empty element in dominant takes its value from recessive
+//   - combine.children="append" on a dominant element adds recessive's children in front of its own
+//   - combine.self="override" on a dominant element keeps it exactly as it is
+// Either side may be nil.
+func MergeConfiguration(dominant, recessive *XMLInner) (*XMLInner, error) {
+	if dominant == nil && recessive == nil {
+		return nil, nil
+	}
+	if dominant == nil {
+		copy := deepCopy(*recessive).(XMLInner)
+		return &copy, nil
+	}
+	if recessive == nil {
+		copy := deepCopy(*dominant).(XMLInner)
+		return &copy, nil
+	}
+	d, err := configurationNode(dominant)
+	if err != nil {
+		return nil, err
+	}
+	r, err := configurationNode(recessive)
+	if err != nil {
+		return nil, err
+	}
+	mergeNode(d, r)
+	return configurationInner(d), nil
+}
+
+// configurationNode parses a configuration into a node standing in for the element it was read from
+func configurationNode(inner *XMLInner) (*node, error) {
+	tree, err := parseTree([]byte(inner.InnerXML))
+	if err != nil {
+		return nil, err
+	}
+	tree.kind = elementNode
+	tree.name.Local = "configuration"
+	tree.attrs = append(tree.attrs, inner.Attrs...)
+	return tree, nil
+}
+
+// configurationInner renders the content of n back into an XMLInner
+func configurationInner(n *node) *XMLInner {
+	inner := &XMLInner{Attrs: n.attrs}
+	if len(n.children) == 0 {
+		return inner
+	}
+	content := &node{kind: elementNode, name: n.name, children: n.children}
+	var b strings.Builder
+	content.render(&b, "", "    ", "\n")
+	rendered := b.String()
+	inner.InnerXML = rendered[len("<configuration>") : len(rendered)-len("</configuration>")]
+	return inner
+}
+
+// nodeAttr returns the value of the attribute name of n, or ""
+func nodeAttr(n *node, name string) string {
+	for _, attr := range n.attrs {
+		if qualifiedName(attr.Name) == name {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// copyNode returns a deep copy of n, attached to parent
+func copyNode(n *node, parent *node) *node {
+	copy := *n
+	copy.parent = parent
+	copy.attrs = append([]xml.Attr{}, n.attrs...)
+	copy.children = make([]*node, 0, len(n.children))
+	for _, c := range n.children {
+		copy.children = append(copy.children, copyNode(c, &copy))
+	}
+	return &copy
+}
+
+// mergeNode merges recessive into dominant, following Xpp3Dom.mergeXpp3Dom
+func mergeNode(dominant, recessive *node) {
+	if nodeAttr(dominant, "combine.self") == "override" {
+		return
+	}
+
+	if !dominant.hasElements() && strings.TrimSpace(dominant.textContent()) == "" &&
+		!recessive.hasElements() && strings.TrimSpace(recessive.textContent()) != "" {
+		dominant.children = make([]*node, 0, len(recessive.children))
+		for _, c := range recessive.children {
+			dominant.children = append(dominant.children, copyNode(c, dominant))
+		}
+	}
+	for _, attr := range recessive.attrs {
+		if nodeAttr(dominant, qualifiedName(attr.Name)) == "" {
+			dominant.attrs = append(dominant.attrs, attr)
+		}
+	}
+
+	if !recessive.hasElements() {
+		return
+	}
+	if nodeAttr(dominant, "combine.children") == "append" {
+		children := make([]*node, 0, len(recessive.children)+len(dominant.children))
+		for _, c := range recessive.children {
+			children = append(children, copyNode(c, dominant))
+		}
+		dominant.children = append(children, dominant.children...)
+		return
+	}
+
+	// Children with the same name are paired up in order.  Any extra
+	// recessive ones are dropped, since dominant has already said how many it wants.
+	named := make(map[string][]*node)
+	for _, c := range dominant.elements() {
+		name := qualifiedName(c.name)
+		named[name] = append(named[name], c)
+	}
+	for _, c := range recessive.elements() {
+		name := qualifiedName(c.name)
+		candidates, ok := named[name]
+		if !ok {
+			dominant.children = append(dominant.children, copyNode(c, dominant))
+			continue
+		}
+		if len(candidates) > 0 {
+			mergeNode(candidates[0], c)
+			named[name] = candidates[1:]
+		}
+	}
+}
+
+// mergeConfigurationQuietly merges two configurations, keeping dominant as it is if either can not be parsed
+func mergeConfigurationQuietly(dominant, recessive *XMLInner) *XMLInner {
+	merged, err := MergeConfiguration(dominant, recessive)
+	if err != nil {
+		return dominant
+	}
+	return merged
+}
+
+// ExecutionConfiguration returns the configuration the execution id of p runs
+// with: the execution's own configuration merged over the plugin's.  Executions
+// that are not declared, like default-compile, run with the plugin's configuration.
+func (p *Plugin) ExecutionConfiguration(id string) (*XMLInner, error) {
+	if p.Executions != nil {
+		for _, execution := range p.Executions.Execution {
+			if executionID(execution) == id {
+				return MergeConfiguration(execution.Configuration, p.Configuration)
+			}
+		}
+	}
+	return MergeConfiguration(nil, p.Configuration)
+}
+
+// EffectivePlugin returns the plugin groupID:artifactID as the build of model
+// runs it: merged with its parents' declarations and with pluginManagement.
+// An empty groupID means org.apache.maven.plugins.  It returns nil if the
+// build does not use the plugin.  Parents are found through resolver.
+// Usage:
+//   compiler, err := pom.EffectivePlugin(model, resolver, "", "maven-compiler-plugin")
+//   conf, err := compiler.Configuration.DOM()
+//   target := conf.Get("target")
+func EffectivePlugin(model Model, resolver Resolver, groupID, artifactID string) (*Plugin, error) {
+	model, err := Effective(model, resolver)
+	if err != nil {
+		return nil, err
+	}
+	if model.Build == nil || model.Build.Plugins == nil {
+		return nil, nil
+	}
+	key := pluginKey(&groupID, &artifactID)
+	for _, plugin := range model.Build.Plugins.Plugin {
+		if pluginKey(plugin.GroupID, plugin.ArtifactID) == key {
+			return plugin, nil
+		}
+	}
+	return nil, nil
+}
+
+// injectPluginManagement merges each plugin of the build with its entry in
+// pluginManagement.  Plugins that are only managed are not added to the build.
+func injectPluginManagement(model Model) Model {
+	if model.Build == nil || model.Build.Plugins == nil ||
+		model.Build.PluginManagement == nil || model.Build.PluginManagement.Plugins == nil {
+		return model
+	}
+	managed := make(map[string]*Plugin)
+	for _, plugin := range model.Build.PluginManagement.Plugins.Plugin {
+		managed[pluginKey(plugin.GroupID, plugin.ArtifactID)] = plugin
+	}
+	for i, plugin := range model.Build.Plugins.Plugin {
+		if m, ok := managed[pluginKey(plugin.GroupID, plugin.ArtifactID)]; ok {
+			copy := deepCopy(*m).(Plugin)
+			model.Build.Plugins.Plugin[i] = managePlugin(plugin, &copy)
+		}
+	}
+	return model
+}
+
+// managePlugin merges the managed declaration of a plugin into the one in the build.
+// Unlike inheritance, the build's executions come first, followed by any only pluginManagement has.
+func managePlugin(plugin, managed *Plugin) *Plugin {
+	plugin.GroupID = firstString(plugin.GroupID, managed.GroupID)
+	plugin.Version = firstString(plugin.Version, managed.Version)
+	plugin.Extensions = firstString(plugin.Extensions, managed.Extensions)
+	plugin.Inherited = firstString(plugin.Inherited, managed.Inherited)
+	if plugin.Goals == nil {
+		plugin.Goals = managed.Goals
+	}
+	plugin.Configuration = mergeConfigurationQuietly(plugin.Configuration, managed.Configuration)
+	plugin.Dependencies = mergeDependencies(plugin.Dependencies, managed.Dependencies)
+
+	if managed.Executions == nil || len(managed.Executions.Execution) == 0 {
+		return plugin
+	}
+	if plugin.Executions == nil {
+		plugin.Executions = &SequenceExecution{}
+	}
+	own := make(map[string]*PluginExecution)
+	for _, execution := range plugin.Executions.Execution {
+		own[executionID(execution)] = execution
+	}
+	for _, execution := range managed.Executions.Execution {
+		existing, ok := own[executionID(execution)]
+		if !ok {
+			plugin.Executions.Execution = append(plugin.Executions.Execution, execution)
+			continue
+		}
+		existing.Phase = firstString(existing.Phase, execution.Phase)
+		existing.Inherited = firstString(existing.Inherited, execution.Inherited)
+		if existing.Goals == nil {
+			existing.Goals = execution.Goals
+		} else if execution.Goals != nil {
+			existing.Goals.Goal = mergeGoals(existing.Goals.Goal, execution.Goals.Goal)
+		}
+		existing.Configuration = mergeConfigurationQuietly(existing.Configuration, execution.Configuration)
+	}
+	return plugin
+}
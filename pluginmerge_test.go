@@ -0,0 +1,142 @@
+package pom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var pluginParentPom = `<project>
+    <groupId>com.example</groupId>
+    <artifactId>plugin-parent</artifactId>
+    <version>1</version>
+    <packaging>pom</packaging>
+    <build>
+        <pluginManagement>
+            <plugins>
+                <plugin>
+                    <artifactId>maven-compiler-plugin</artifactId>
+                    <version>3.8.1</version>
+                    <configuration>
+                        <source>1.8</source>
+                        <target>1.8</target>
+                        <compilerArgs>
+                            <arg>-Xlint</arg>
+                        </compilerArgs>
+                        <excludes>
+                            <exclude>**/Legacy*.java</exclude>
+                        </excludes>
+                    </configuration>
+                    <executions>
+                        <execution>
+                            <id>generated</id>
+                            <phase>generate-sources</phase>
+                            <goals><goal>compile</goal></goals>
+                            <configuration><encoding>UTF-8</encoding></configuration>
+                        </execution>
+                    </executions>
+                </plugin>
+            </plugins>
+        </pluginManagement>
+        <plugins>
+            <plugin>
+                <artifactId>maven-compiler-plugin</artifactId>
+                <configuration>
+                    <showWarnings>true</showWarnings>
+                </configuration>
+            </plugin>
+        </plugins>
+    </build>
+</project>`
+
+var pluginChildPom = `<project>
+    <parent>
+        <groupId>com.example</groupId>
+        <artifactId>plugin-parent</artifactId>
+        <version>1</version>
+    </parent>
+    <artifactId>plugin-child</artifactId>
+    <build>
+        <plugins>
+            <plugin>
+                <artifactId>maven-compiler-plugin</artifactId>
+                <configuration>
+                    <target>11</target>
+                    <compilerArgs combine.children="append">
+                        <arg>-parameters</arg>
+                    </compilerArgs>
+                    <excludes combine.self="override"/>
+                </configuration>
+                <executions>
+                    <execution>
+                        <id>generated</id>
+                        <configuration><encoding/></configuration>
+                    </execution>
+                </executions>
+            </plugin>
+        </plugins>
+    </build>
+</project>`
+
+func TestMergeConfiguration(t *testing.T) {
+	a := assert.New(t)
+	dominant := &XMLInner{InnerXML: `<items><item>a</item></items><empty/><name>x</name>`}
+	recessive := &XMLInner{InnerXML: `<items><item>b</item><item>c</item></items><empty>filled</empty><other attr="1">y</other>`}
+	merged, err := MergeConfiguration(dominant, recessive)
+	a.NoError(err)
+	conf, err := merged.DOM()
+	a.NoError(err)
+	a.Len(conf.Get("items").Children(), 1, "extra recessive children are dropped")
+	a.Equal("a", conf.Get("items/item").Text())
+	a.Equal("filled", conf.Get("empty").Text())
+	a.Equal("x", conf.Get("name").Text())
+	value, _ := conf.Get("other").Attr("attr")
+	a.Equal("1", value)
+	a.Equal(`<items><item>a</item></items><empty/><name>x</name>`, dominant.InnerXML, "dominant is not changed")
+
+	_, err = MergeConfiguration(dominant, &XMLInner{InnerXML: "<broken>"})
+	a.Error(err)
+}
+
+func TestEffectivePlugin(t *testing.T) {
+	a := assert.New(t)
+	resolver := mapResolver{"com.example:plugin-parent:1": pluginParentPom}
+	child, err := Unmarshal([]byte(pluginChildPom))
+	a.NoError(err)
+
+	compiler, err := EffectivePlugin(child, resolver, "org.apache.maven.plugins", "maven-compiler-plugin")
+	a.NoError(err)
+	a.Equal("3.8.1", *compiler.Version, "version comes from pluginManagement")
+
+	conf, err := compiler.Configuration.DOM()
+	a.NoError(err)
+	a.Equal("11", conf.Get("target").Text())
+	a.Equal("1.8", conf.Get("source").Text())
+	a.Equal("true", conf.Get("showWarnings").Text())
+	args := make([]string, 0)
+	for _, arg := range conf.Get("compilerArgs").Children() {
+		args = append(args, arg.Text())
+	}
+	a.Equal([]string{"-Xlint", "-parameters"}, args)
+	a.Len(conf.Get("excludes").Children(), 0, "combine.self=override keeps the child's element as it is")
+
+	a.Len(compiler.Executions.Execution, 1)
+	execution := compiler.Executions.Execution[0]
+	a.Equal("generate-sources", *execution.Phase)
+	executionConf, err := compiler.ExecutionConfiguration("generated")
+	a.NoError(err)
+	dom, err := executionConf.DOM()
+	a.NoError(err)
+	a.Equal("UTF-8", dom.Get("encoding").Text())
+	a.Equal("11", dom.Get("target").Text(), "execution configuration includes the plugin's")
+
+	executionConf, err = compiler.ExecutionConfiguration("default-compile")
+	a.NoError(err)
+	dom, err = executionConf.DOM()
+	a.NoError(err)
+	a.Nil(dom.Get("encoding"))
+
+	missing, err := EffectivePlugin(child, resolver, "", "maven-surefire-plugin")
+	a.NoError(err)
+	a.Nil(missing)
+}
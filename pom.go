@@ -3,27 +3,259 @@ package pom
 //go:generate go run gen/main.go gen/models.go gen/templates.go gen/build.go
 
 import (
+	"bytes"
 	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
 	"strings"
 )
 
-var pomProjectHeader = `<project xmlns="http://maven.apache.org/POM/4.0.0" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:schemaLocation="http://maven.apache.org/POM/4.0.0 http://maven.apache.org/xsd/maven-4.0.0.xsd">`
+const (
+	xsiNamespace      = "http://www.w3.org/2001/XMLSchema-instance"
+	pomSchemaLocation = pomNamespace + " http://maven.apache.org/xsd/maven-4.0.0.xsd"
+)
 
-// Unmarshal takes in the raw data of a POM, and returns a project in the form of a Model
+// Unmarshal takes in the raw data of a POM, and returns a project in the form of a Model.
+// If the POM can not be read, the error is a *SyntaxError or *TypeError saying where.
+// POMs that declare a charset other than UTF-8 are read in that charset, which is kept as the Model's Encoding.
 func Unmarshal(rawPom []byte) (Model, error) {
+	data, charset, err := toUTF8(rawPom)
+	if err != nil {
+		return Model{}, err
+	}
+	model, err := unmarshalUTF8("", data)
+	model.Encoding = charset
+	return model, err
+}
+
+// unmarshalUTF8 is Unmarshal for a POM that has already been turned into UTF-8.
+// file labels any error.
+func unmarshalUTF8(file string, data []byte) (Model, error) {
 	pom := project{}
-	err := xml.Unmarshal(rawPom, &pom)
+	d := xml.NewDecoder(bytes.NewReader(data))
+	d.CharsetReader = passthroughCharsetReader
+	err := d.Decode(&pom)
+	if err != nil {
+		err = locateError(file, data, err)
+	}
 	return pom.Model, err
 }
 
-// Marshal turns a POM project into the raw bytes of a pom, ready for export
+// Marshal turns a POM project into the raw bytes of a pom, ready for export.
+// It is written in the Model's Encoding.
 func Marshal(pom Model) ([]byte, error) {
-	p := project{pom}
-	data, err := xml.MarshalIndent(p, "", "    ")
-	if err != nil {
-		return data, err
+	var b bytes.Buffer
+	err := NewEncoder(&b).Encode(pom)
+	return b.Bytes(), err
+}
+
+// Decoder reads POMs from a stream, without reading the whole stream in first
+type Decoder struct {
+	// CharsetReader is used to read POMs that are not UTF-8, the same as in encoding/xml.
+	// It is CharsetReader to start with.
+	CharsetReader func(charset string, input io.Reader) (io.Reader, error)
+
+	r       *recorder
+	d       *xml.Decoder
+	charset string
+}
+
+// NewDecoder returns a Decoder that reads from r
+// Usage:
+//   resp, err := http.Get("https://repo.maven.apache.org/maven2/junit/junit/4.12/junit-4.12.pom")
+//   var model pom.Model
+//   err = pom.NewDecoder(resp.Body).Decode(&model)
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{r: &recorder{r: r}, CharsetReader: CharsetReader}
+}
+
+// Decode reads the next project from the stream into model.
+// It returns io.EOF once there are no more projects.
+// A *SyntaxError has the line and the path of the element it is in, but unlike Unmarshal
+// no column.  Its message is the one from encoding/xml.
+func (d *Decoder) Decode(model *Model) error {
+	if d.d == nil {
+		d.d = xml.NewDecoder(d.r)
+		if d.CharsetReader != nil {
+			d.d.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
+				// Remember the charset, as the rest of the stream is read in it
+				d.charset = charset
+				return d.CharsetReader(charset, input)
+			}
+		}
+	}
+	// Only the project being read is kept, to find where any problem with it is
+	d.r.discard(d.d.InputOffset())
+	pom := project{}
+	if err := d.d.Decode(&pom); err != nil {
+		if syntaxErr, ok := err.(*xml.SyntaxError); ok {
+			path := elementPath(d.r.upTo(d.d.InputOffset()))
+			return &SyntaxError{Location: Location{Line: syntaxErr.Line, Path: path}, Msg: syntaxErr.Msg}
+		}
+		return err
+	}
+	*model = pom.Model
+	if !isUTF8(d.charset) {
+		model.Encoding = d.charset
+	}
+	return nil
+}
+
+// recorder keeps what is read from r, starting from the offset base
+type recorder struct {
+	r    io.Reader
+	base int64
+	data []byte
+}
+
+func (r *recorder) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.data = append(r.data, p[:n]...)
+	return n, err
+}
+
+// discard forgets everything before offset
+func (r *recorder) discard(offset int64) {
+	r.data = append(r.data[:0], r.data[offset-r.base:]...)
+	r.base = offset
+}
+
+// upTo returns what was kept, up to offset
+func (r *recorder) upTo(offset int64) []byte {
+	if end := offset - r.base; end < int64(len(r.data)) {
+		return r.data[:end]
+	}
+	return r.data
+}
+
+// elementPath returns the path of the element raw stops in, in the form Positions.Lookup takes.
+// Only later elements of the same name get an index, as the first is found without one.
+func elementPath(raw []byte) string {
+	d := xml.NewDecoder(bytes.NewReader(raw))
+	d.CharsetReader = passthroughCharsetReader
+	steps, names := make([]string, 0), make([]string, 0)
+	counts := []map[string]int{{}}
+	for {
+		token, err := d.RawToken()
+		if err != nil {
+			return strings.Join(steps, "/")
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			step, seen := t.Name.Local, counts[len(counts)-1]
+			if index := seen[step]; index > 0 && len(steps) > 0 {
+				step += "[" + strconv.Itoa(index) + "]"
+			}
+			seen[t.Name.Local]++
+			steps, names = append(steps, step), append(names, t.Name.Local)
+			counts = append(counts, map[string]int{})
+		case xml.EndElement:
+			// RawToken does not match end elements up, so one that does not match is the problem
+			if len(names) == 0 || names[len(names)-1] != t.Name.Local {
+				return strings.Join(steps, "/")
+			}
+			steps, names = steps[:len(steps)-1], names[:len(names)-1]
+			counts = counts[:len(counts)-1]
+		}
+	}
+}
+
+// Encoder writes POMs to a stream.  NewEncoder sets every option to what Marshal uses,
+// and they can be changed before calling Encode.
+type Encoder struct {
+	// Indent is added for each level of elements.  No indentation writes the POM on one line.
+	Indent string
+	// Newline ends each line, like "\n" or "\r\n"
+	Newline string
+	// Declaration writes the <?xml?> declaration at the start
+	Declaration bool
+	// Namespace is the default namespace of the project element, if it is set
+	Namespace string
+	// SchemaLocation is written as the project element's xsi:schemaLocation, if it is set
+	SchemaLocation string
+	// Charset is the encoding declared and written.  When it is empty, each Model is
+	// written in its own Encoding, or UTF-8 if it has none.
+	Charset string
+	// CharsetWriter is used to write any charset other than UTF-8.  It is CharsetWriter to start with.
+	CharsetWriter func(charset string, output io.Writer) (io.WriteCloser, error)
+
+	w io.Writer
+}
+
+// NewEncoder returns an Encoder that writes to w
+// Usage:
+//   enc := pom.NewEncoder(os.Stdout)
+//   enc.Indent = "  "
+//   err := enc.Encode(model)
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{
+		Indent:         "    ",
+		Newline:        "\n",
+		Declaration:    true,
+		Namespace:      pomNamespace,
+		SchemaLocation: pomSchemaLocation,
+		CharsetWriter:  CharsetWriter,
+		w:              w,
+	}
+}
+
+// Encode writes model as a project
+func (e *Encoder) Encode(model Model) error {
+	charset := e.Charset
+	if charset == "" {
+		charset = model.Encoding
+	}
+	if charset == "" {
+		charset = "UTF-8"
+	}
+	var w io.WriteCloser = nopCloser{e.w}
+	if !isUTF8(charset) {
+		if e.CharsetWriter == nil {
+			return fmt.Errorf("pom: cannot write charset %s without a CharsetWriter", charset)
+		}
+		var err error
+		if w, err = e.CharsetWriter(charset, e.w); err != nil {
+			return err
+		}
+	}
+	if e.Newline != "\n" {
+		w = &newlineWriter{WriteCloser: w, newline: []byte(e.Newline)}
+	}
+
+	if e.Declaration {
+		if _, err := io.WriteString(w, `<?xml version="1.0" encoding="`+charset+`"?>`+"\n"); err != nil {
+			return err
+		}
+	}
+	start := xml.StartElement{Name: xml.Name{Space: e.Namespace, Local: "project"}}
+	if e.SchemaLocation != "" {
+		start.Attr = append(start.Attr,
+			xml.Attr{Name: xml.Name{Local: "xmlns:xsi"}, Value: xsiNamespace},
+			xml.Attr{Name: xml.Name{Local: "xsi:schemaLocation"}, Value: e.SchemaLocation})
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", e.Indent)
+	if err := enc.EncodeElement(project{model}, start); err != nil {
+		return err
+	}
+	if err := enc.Flush(); err != nil {
+		return err
+	}
+	// Charset writers can hold on to the end of what they were given
+	return w.Close()
+}
+
+// newlineWriter writes each \n as newline.  encoding/xml escapes line breaks in text,
+// so the only ones left are between elements and inside of XMLInner.
+type newlineWriter struct {
+	io.WriteCloser
+	newline []byte
+}
+
+func (w *newlineWriter) Write(p []byte) (int, error) {
+	if _, err := w.WriteCloser.Write(bytes.Replace(p, []byte("\n"), w.newline, -1)); err != nil {
+		return 0, err
 	}
-	data = append([]byte(xml.Header), data...)
-	data = []byte(strings.Replace(string(data), "<project>", pomProjectHeader, 1))
-	return data, err
+	return len(p), nil
 }
@@ -316,6 +316,14 @@ var reMarshaledPom = `<?xml version="1.0" encoding="UTF-8"?>
                 <groupId>org.apache.maven.plugins</groupId>
                 <artifactId>maven-source-plugin</artifactId>
                 <version>2.2.1</version>
+                <executions>
+                    <execution>
+                        <id>attach-sources</id>
+                        <goals>
+                            <goal>jar</goal>
+                        </goals>
+                    </execution>
+                </executions>
             </plugin>
             <plugin>
                 <groupId>org.apache.maven.plugins</groupId>
@@ -336,6 +344,13 @@ var reMarshaledPom = `<?xml version="1.0" encoding="UTF-8"?>
                 <groupId>org.codehaus.mojo</groupId>
                 <artifactId>findbugs-maven-plugin</artifactId>
                 <version>3.0.1</version>
+                <executions>
+                    <execution>
+                        <goals>
+                            <goal>check</goal>
+                        </goals>
+                    </execution>
+                </executions>
                 <configuration>
                     <effort>Max</effort>
                     <excludeFilterFile>${basedir}/findbugs-exclude.xml</excludeFilterFile>
@@ -348,6 +363,51 @@ var reMarshaledPom = `<?xml version="1.0" encoding="UTF-8"?>
                 <groupId>org.apache.cxf</groupId>
                 <artifactId>cxf-codegen-plugin</artifactId>
                 <version>3.2.6</version>
+                <executions>
+                    <execution>
+                        <id>generate-sources</id>
+                        <phase>generate-sources</phase>
+                        <goals>
+                            <goal>wsdl2java</goal>
+                        </goals>
+                        <configuration>
+                            <defaultOptions>
+                                <bindingFiles>
+                                    <!-- These come from the MSODS team -->
+                                    <bindingFile>${wsdl.dir}/DirectoryChange.xsd</bindingFile>
+                                    <bindingFile>${wsdl.dir}/DirectorySync.xsd</bindingFile>
+                                    <bindingFile>${wsdl.dir}/DirectorySync2.xsd</bindingFile>
+                                    <bindingFile>${wsdl.dir}/DirectorySyncMetadata.xsd</bindingFile>
+                                    <bindingFile>${wsdl.dir}/Serialization.Arrays.xsd</bindingFile>
+                                    <bindingFile>${wsdl.dir}/Serialization.xsd</bindingFile>
+                                    <bindingFile>${wsdl.dir}/ServiceInstanceMove.xsd</bindingFile>
+                                    <bindingFile>${wsdl.dir}/System.xsd</bindingFile>
+                                    <bindingFile>${wsdl.dir}/Annotations.xsd</bindingFile>
+                                    <!-- This is from the O365 team -->
+                                    <bindingFile>${wsdl.dir}/ExtensibilitySchema.xsd</bindingFile>
+                                </bindingFiles>
+                                <extraargs>
+                                    <extraarg>-xjc-npa</extraarg>
+                                </extraargs>
+                            </defaultOptions>
+                            <sourceRoot>${basedir}/target/generated-sources/wsimport</sourceRoot>
+                            <wsdlOptions>
+                                <wsdlOPtion>
+                                    <wsdl>${basedir}/src/main/resources/wsdl-modified/ServiceInstanceMove.wsdl</wsdl>
+                                    <wsdlLocation>classpath:wsdl-modified/ServiceInstanceMove.wsdl</wsdlLocation>
+                                </wsdlOPtion>
+                                <wsdlOPtion>
+                                    <wsdl>${basedir}/src/main/resources/wsdl-modified/FederatedServiceOnboarding.wsdl</wsdl>
+                                    <wsdlLocation>classpath:wsdl-modified/FederatedServiceOnboarding.wsdl</wsdlLocation>
+                                </wsdlOPtion>
+                                <wsdlOption>
+                                    <wsdl>${basedir}/src/main/resources/wsdl-modified/DirectorySync.wsdl</wsdl>
+                                    <wsdlLocation>classpath:wsdl-modified/DirectorySync.wsdl</wsdlLocation>
+                                </wsdlOption>
+                            </wsdlOptions>
+                        </configuration>
+                    </execution>
+                </executions>
                 <!-- Run this plugin after copying over new schema files. NOTE: they require hand-editing
                  for this plugin to succeed. See the README.md of this repo for the wiki on how to do that. -->
             </plugin>
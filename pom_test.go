@@ -1,6 +1,11 @@
 package pom
 
 import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -56,3 +61,203 @@ func TestUpdatedSequence(t *testing.T) {
 		a.Equal(testName, name, "Name does not match")
 	}
 }
+
+func TestAttributesPersist(t *testing.T) {
+	a := assert.New(t)
+	raw := `<project child.project.url.inherit.append.path="false">
+    <scm child.scm.url.inherit.append.path="false">
+        <url>https://example.com/scm</url>
+    </scm>
+    <build>
+        <plugins>
+            <plugin>
+                <artifactId>maven-compiler-plugin</artifactId>
+                <configuration combine.self="override">
+                    <compilerArgs combine.children="append">
+                        <arg>-Xlint</arg>
+                    </compilerArgs>
+                </configuration>
+            </plugin>
+        </plugins>
+    </build>
+</project>`
+	pom, err := Unmarshal([]byte(raw))
+	a.NoError(err, "Error unmarshalling test data")
+	appendPath, _ := pom.GetChildProjectURLInheritAppendPath()
+	a.Equal("false", appendPath, "Project attribute was not read")
+	rawPom, err := Marshal(pom)
+	a.NoError(err, "Error marshalling test data")
+	a.Contains(string(rawPom), `<project xmlns="http://maven.apache.org/POM/4.0.0" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:schemaLocation="http://maven.apache.org/POM/4.0.0 http://maven.apache.org/xsd/maven-4.0.0.xsd" child.project.url.inherit.append.path="false">`)
+	a.Contains(string(rawPom), `<scm child.scm.url.inherit.append.path="false">`)
+	a.Contains(string(rawPom), `<configuration combine.self="override">`)
+	a.Contains(string(rawPom), `<compilerArgs combine.children="append">`)
+}
+
+func TestSequenceMethods(t *testing.T) {
+	a := assert.New(t)
+	module := func(name string) *string { return &name }
+	modules := &SequenceModule{}
+	modules.AddModule(module("a"))
+	modules.AddModule(module("c"))
+
+	modules.UpdateModule(module("b"), 1)
+	a.Equal(2, modules.LenModule(), "Update should not append when the index exists")
+	a.Equal("b", *modules.Module[1])
+
+	modules.InsertModule(module("first"), 0)
+	modules.InsertModule(module("last"), 10)
+	modules.InsertModule(module("middle"), 2)
+	names := make([]string, 0)
+	for _, m := range modules.GetModule() {
+		names = append(names, *m)
+	}
+	a.Equal([]string{"first", "a", "middle", "b", "last"}, names)
+
+	index, found := modules.FindModule(func(m *string) bool { return *m == "middle" })
+	a.Equal(2, index)
+	a.Equal("middle", *found)
+	index, found = modules.FindModule(func(m *string) bool { return *m == "missing" })
+	a.Equal(-1, index)
+	a.Nil(found)
+
+	a.True(modules.RemoveModule(2))
+	a.False(modules.RemoveModule(10))
+	a.False(modules.RemoveModule(-1))
+	a.Equal(4, modules.LenModule())
+	a.Equal("a", *modules.Module[1])
+
+	modules.ClearModule()
+	a.Equal(0, modules.LenModule())
+	a.Nil(modules.Module)
+}
+
+type mapHolder struct {
+	XMLName xml.Name      `xml:"holder"`
+	Map     XMLMap        `xml:"map"`
+	Ordered XMLOrderedMap `xml:"ordered"`
+	After   string        `xml:"after"`
+}
+
+func TestXMLMapOrder(t *testing.T) {
+	a := assert.New(t)
+	raw := `<holder><map><zeta>1</zeta><alpha>2</alpha><mid>3</mid></map><ordered><zeta>1</zeta><alpha>2</alpha><zeta>4</zeta><mid>3</mid></ordered><after>x</after></holder>`
+	holder := mapHolder{}
+	a.NoError(xml.Unmarshal([]byte(raw), &holder))
+	a.Equal(XMLMap{"zeta": "1", "alpha": "2", "mid": "3"}, holder.Map)
+	a.Equal([]string{"zeta", "alpha", "mid"}, holder.Ordered.Keys())
+	value, _ := holder.Ordered.Get("zeta")
+	a.Equal("4", value, "the last value wins")
+	a.Equal("x", holder.After, "the maps should not read past their own elements")
+
+	// The same bytes come out every time
+	first, err := xml.Marshal(holder)
+	a.NoError(err)
+	for i := 0; i < 20; i++ {
+		again, err := xml.Marshal(holder)
+		a.NoError(err)
+		a.Equal(string(first), string(again))
+	}
+	a.Equal(`<holder><map><alpha>2</alpha><mid>3</mid><zeta>1</zeta></map><ordered><zeta>4</zeta><alpha>2</alpha><mid>3</mid></ordered><after>x</after></holder>`, string(first))
+
+	a.True(holder.Ordered.Delete("alpha"))
+	a.False(holder.Ordered.Delete("alpha"))
+	holder.Ordered.Set("new", "5")
+	a.Equal([]string{"zeta", "mid", "new"}, holder.Ordered.Keys())
+	a.Equal(3, holder.Ordered.Len())
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestXMLMapEncodeErrors(t *testing.T) {
+	a := assert.New(t)
+	err := xml.NewEncoder(failingWriter{}).Encode(XMLMap{"key": "value"})
+	a.EqualError(err, "disk full")
+
+	ordered := XMLOrderedMap{}
+	ordered.Set("key", "value")
+	err = xml.NewEncoder(failingWriter{}).Encode(ordered)
+	a.EqualError(err, "disk full")
+}
+
+func TestDecoder(t *testing.T) {
+	a := assert.New(t)
+	stream := strings.NewReader(examplePom + "\n<project><artifactId>second</artifactId></project>")
+	d := NewDecoder(stream)
+
+	var model Model
+	a.NoError(d.Decode(&model))
+	expected, err := Unmarshal([]byte(examplePom))
+	a.NoError(err)
+	a.Equal(expected, model)
+	a.NoError(d.Decode(&model))
+	a.Equal("second", *model.ArtifactID)
+	a.Equal(io.EOF, d.Decode(&model))
+
+	err = NewDecoder(strings.NewReader("<project>\n<name>a & b</name></project>")).Decode(&model)
+	a.EqualError(err, "pom: 2: project/name: invalid character entity & (no semicolon)")
+	a.IsType(&SyntaxError{}, err)
+
+	// Paths are those of the project being read, and can be looked up in it
+	d = NewDecoder(strings.NewReader(`<project><artifactId>first</artifactId></project>
+<project>
+    <dependencies>
+        <dependency><artifactId>a</artifactId></dependency>
+        <dependency><artifactId>b</artifactId></dependency>
+        <dependency><artifactId>c</version></dependency>
+    </dependencies>
+</project>`))
+	a.NoError(d.Decode(&model))
+	err = d.Decode(&model)
+	a.EqualError(err, "pom: 6: project/dependencies/dependency[2]/artifactId: element <artifactId> closed by </version>")
+}
+
+func TestEncoder(t *testing.T) {
+	a := assert.New(t)
+	model := Model{ModelVersion: optional("4.0.0"), ArtifactID: optional("encoded")}
+	model.Properties = &XMLProperties{}
+	model.Properties.Set("java.version", "11")
+	model.Properties.Elements[0].Comment = xml.Comment(" the lowest we support ")
+
+	var b bytes.Buffer
+	a.NoError(NewEncoder(&b).Encode(model))
+	marshalled, err := Marshal(model)
+	a.NoError(err)
+	a.Equal(string(marshalled), b.String())
+
+	b.Reset()
+	enc := NewEncoder(&b)
+	enc.Indent = "\t"
+	enc.Newline = "\r\n"
+	enc.Declaration = false
+	enc.SchemaLocation = ""
+	a.NoError(enc.Encode(model))
+	a.Equal("<project xmlns=\"http://maven.apache.org/POM/4.0.0\">\r\n"+
+		"\t<modelVersion>4.0.0</modelVersion>\r\n"+
+		"\t<artifactId>encoded</artifactId>\r\n"+
+		"\t<properties>\r\n"+
+		"\t\t<!-- the lowest we support -->\r\n"+
+		"\t\t<java.version>11</java.version>\r\n"+
+		"\t</properties>\r\n"+
+		"</project>", b.String())
+
+	b.Reset()
+	enc = NewEncoder(&b)
+	enc.Indent = ""
+	enc.Namespace = ""
+	enc.SchemaLocation = "urn:example pom.xsd"
+	model.Properties = nil
+	a.NoError(enc.Encode(model))
+	a.Equal(`<?xml version="1.0" encoding="UTF-8"?>`+"\n"+
+		`<project xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:schemaLocation="urn:example pom.xsd">`+
+		`<modelVersion>4.0.0</modelVersion><artifactId>encoded</artifactId></project>`, b.String())
+
+	enc.Charset = "KOI8-R"
+	a.EqualError(enc.Encode(model), "pom: unsupported charset KOI8-R")
+	enc.CharsetWriter = nil
+	a.EqualError(enc.Encode(model), "pom: cannot write charset KOI8-R without a CharsetWriter")
+	a.EqualError(NewEncoder(failingWriter{}).Encode(model), "disk full")
+}
@@ -0,0 +1,236 @@
+package pom
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Position is where an element was written in a POM.  Lines and columns
+// start at 1, and columns count bytes, the same as go/token.
+// The end is just past the element's end tag.
+type Position struct {
+	Offset, EndOffset  int
+	Line, Column       int
+	EndLine, EndColumn int
+}
+
+func (p Position) String() string {
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
+}
+
+// Positions records where each element of a POM was written.  Elements can be
+// looked up by their path in the model, or by the pointer in a Model they were read into.
+//
+// Each step of a path can pick an element by its index, like project/dependencies/dependency[2]/version,
+// or leave the index off for the first element of that name, like project/build/plugins.
+// Dependencies, plugins, exclusions and anything with an id can also be picked
+// by their key, the same way Problem paths are written:
+//   - project/dependencies/dependency[junit:junit:jar]
+//   - project/build/plugins/plugin[org.apache.maven.plugins:maven-compiler-plugin]
+//   - project/profiles/profile[ci]
+type Positions struct {
+	root   *node
+	lines  []int
+	values map[interface{}]Position
+}
+
+// ReadPositions finds where every element in rawPom was written.  Offsets and columns
+// of POMs in other charsets count the bytes they take up in UTF-8.
+// If model is the Model rawPom was unmarshalled into, positions can also be found with Of.
+// Usage:
+//   model, err := pom.Unmarshal(raw)
+//   positions, err := pom.ReadPositions(raw, &model)
+//   for _, problem := range model.Validate(pom.ValidationLevelStrict) {
+//       position, _ := positions.Find(problem.Path)
+//       fmt.Printf("pom.xml:%s: %s\n", position, problem)
+//   }
+func ReadPositions(rawPom []byte, model *Model) (*Positions, error) {
+	data, _, err := toUTF8(rawPom)
+	if err != nil {
+		return nil, err
+	}
+	return readPositions(data, model)
+}
+
+// readPositions is ReadPositions for a POM that has already been turned into UTF-8
+func readPositions(rawPom []byte, model *Model) (*Positions, error) {
+	tree, err := parseTree(rawPom)
+	if err != nil {
+		return nil, err
+	}
+	p := &Positions{root: firstElement(tree), lines: lineOffsets(rawPom), values: make(map[interface{}]Position)}
+	if p.root != nil && model != nil {
+		p.readValues(p.root, reflect.ValueOf(model).Elem())
+	}
+	return p, nil
+}
+
+// Lookup returns the position of the element at path
+func (p *Positions) Lookup(path string) (Position, bool) {
+	if p == nil {
+		return Position{}, false
+	}
+	n := p.lookup(path)
+	if n == nil {
+		return Position{}, false
+	}
+	return p.position(n), true
+}
+
+// Find returns the position of the element at path, or of the closest element
+// above it if it was not written, along with the path that was found.
+// A problem with a missing version is reported on the dependency it belongs to, for example.
+func (p *Positions) Find(path string) (Position, string) {
+	for path != "" {
+		if position, ok := p.Lookup(path); ok {
+			return position, path
+		}
+		i := strings.LastIndex(path, "/")
+		if i < 0 {
+			break
+		}
+		path = path[:i]
+	}
+	return Position{}, ""
+}
+
+// Of returns the position of the element that value was read from.  value has to be a
+// pointer inside of the Model the positions were read with, like a *Dependency or its Version.
+// Usage:
+//   position, ok := positions.Of(model.Dependencies.Dependency[0].Version)
+func (p *Positions) Of(value interface{}) (Position, bool) {
+	if p == nil || value == nil || reflect.TypeOf(value).Kind() != reflect.Ptr {
+		return Position{}, false
+	}
+	position, ok := p.values[value]
+	return position, ok
+}
+
+// lineOffsets returns the offset of the start of each line in raw
+func lineOffsets(raw []byte) []int {
+	lines := []int{0}
+	for i, b := range raw {
+		if b == '\n' {
+			lines = append(lines, i+1)
+		}
+	}
+	return lines
+}
+
+// position works out the lines and columns of the node n
+func (p *Positions) position(n *node) Position {
+	position := Position{Offset: n.start, EndOffset: n.end}
+	position.Line, position.Column = lineColumn(p.lines, n.start)
+	position.EndLine, position.EndColumn = lineColumn(p.lines, n.end)
+	return position
+}
+
+// lookup finds the element at path by walking down from the root one step at a time
+func (p *Positions) lookup(path string) *node {
+	steps := strings.Split(path, "/")
+	if p.root == nil || steps[0] != p.root.name.Local {
+		return nil
+	}
+	n := p.root
+	for _, step := range steps[1:] {
+		name, selector := step, ""
+		if i := strings.Index(step, "["); i >= 0 && strings.HasSuffix(step, "]") {
+			name, selector = step[:i], step[i+1:len(step)-1]
+		}
+		candidates := namedElements(n, name)
+		n = nil
+		if index, err := strconv.Atoi(selector); err == nil {
+			if index >= 0 && index < len(candidates) {
+				n = candidates[index]
+			}
+		} else {
+			// The first declaration wins, which is what duplicates are reported against
+			for _, c := range candidates {
+				if selector == "" || elementKey(c) == selector {
+					n = c
+					break
+				}
+			}
+		}
+		if n == nil {
+			return nil
+		}
+	}
+	return n
+}
+
+// elementKey returns the key an element can be looked up with, or "" if it has none
+func elementKey(n *node) string {
+	text := func(name string) *string {
+		found := namedElements(n, name)
+		if len(found) == 0 {
+			return nil
+		}
+		value := strings.TrimSpace(found[0].textContent())
+		return &value
+	}
+	switch n.name.Local {
+	case "dependency":
+		d := &Dependency{GroupID: text("groupId"), ArtifactID: text("artifactId"), Type: text("type"), Classifier: text("classifier")}
+		return strings.TrimSuffix(dependencyKey(d), ":")
+	case "plugin":
+		return pluginKey(text("groupId"), text("artifactId"))
+	case "exclusion":
+		return trimmed(text("groupId")) + ":" + trimmed(text("artifactId"))
+	}
+	if id := text("id"); id != nil && *id != "" {
+		return *id
+	}
+	return ""
+}
+
+// readValues records the position of each element below n against the pointer
+// in v it was unmarshalled into.  v is the struct n was unmarshalled into.
+func (p *Positions) readValues(n *node, v reflect.Value) {
+	fields := make(map[string]int)
+	anyField := -1
+	for i := 0; i < v.NumField(); i++ {
+		tag := v.Type().Field(i).Tag.Get("xml")
+		name := strings.Split(tag, ",")[0]
+		if name != "" {
+			fields[name] = i
+		} else if tag == ",any" {
+			anyField = i
+		}
+	}
+	counts := make(map[int]int)
+	for _, c := range n.elements() {
+		i, ok := fields[c.name.Local]
+		if !ok {
+			if anyField < 0 {
+				continue
+			}
+			i = anyField
+		}
+		field := v.Field(i)
+		switch field.Kind() {
+		case reflect.Slice:
+			index := counts[i]
+			counts[i]++
+			if index >= field.Len() {
+				continue
+			}
+			field = field.Index(index)
+		case reflect.Map:
+			// Map values can not be pointed to
+			continue
+		}
+		if field.Kind() != reflect.Ptr {
+			field = field.Addr()
+		}
+		if field.IsNil() {
+			continue
+		}
+		p.values[field.Interface()] = p.position(c)
+		if field.Elem().Kind() == reflect.Struct {
+			p.readValues(c, field.Elem())
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package pom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var positionsPom = `<project>
+    <artifactId>positions</artifactId>
+    <dependencies>
+        <dependency>
+            <groupId>junit</groupId>
+            <artifactId>junit</artifactId>
+        </dependency>
+        <dependency>
+            <groupId>org.slf4j</groupId>
+            <artifactId>slf4j-api</artifactId>
+            <version>1.7.28</version>
+        </dependency>
+    </dependencies>
+    <properties>
+        <java.version>11</java.version>
+    </properties>
+    <profiles>
+        <profile><id>ci</id></profile>
+    </profiles>
+</project>`
+
+func TestPositionsByPath(t *testing.T) {
+	a := assert.New(t)
+	positions, err := ReadPositions([]byte(positionsPom), nil)
+	a.NoError(err)
+
+	position, ok := positions.Lookup("project/artifactId")
+	a.True(ok)
+	a.Equal(Position{Offset: 14, EndOffset: 48, Line: 2, Column: 5, EndLine: 2, EndColumn: 39}, position)
+	a.Equal("2:5", position.String())
+
+	byIndex, ok := positions.Lookup("project/dependencies/dependency[1]/version")
+	a.True(ok)
+	a.Equal(11, byIndex.Line)
+	a.Equal(13, byIndex.Column)
+	byKey, ok := positions.Lookup("project/dependencies[0]/dependency[org.slf4j:slf4j-api:jar]/version")
+	a.True(ok)
+	a.Equal(byIndex, byKey)
+
+	position, ok = positions.Lookup("project/profiles/profile[ci]")
+	a.True(ok)
+	a.Equal(18, position.Line)
+	_, ok = positions.Lookup("project/dependencies/dependency[2]")
+	a.False(ok)
+	_, ok = positions.Lookup("parent/groupId")
+	a.False(ok)
+
+	// Nothing was written for the version, so the dependency is found instead
+	position, found := positions.Find("project/dependencies/dependency[junit:junit:jar]/version")
+	a.Equal("project/dependencies/dependency[junit:junit:jar]", found)
+	a.Equal(4, position.Line)
+	a.Equal(7, position.EndLine)
+	a.Equal(22, position.EndColumn)
+}
+
+func TestPositionsByValue(t *testing.T) {
+	a := assert.New(t)
+	doc, err := UnmarshalDocument([]byte(positionsPom))
+	a.NoError(err)
+	positions := doc.Positions()
+
+	slf4j := doc.Model.Dependencies.Dependency[1]
+	position, ok := positions.Of(slf4j)
+	a.True(ok)
+	a.Equal(8, position.Line)
+	position, ok = positions.Of(slf4j.Version)
+	a.True(ok)
+	a.Equal(11, position.Line)
+	position, ok = positions.Of(&doc.Model.Properties.Elements[0])
+	a.True(ok)
+	a.Equal(15, position.Line)
+	position, ok = positions.Of(doc.Model.Profiles.Profile[0].ID)
+	a.True(ok)
+	a.Equal(Position{Offset: 487, EndOffset: 498, Line: 18, Column: 18, EndLine: 18, EndColumn: 29}, position)
+
+	_, ok = positions.Of(&Dependency{})
+	a.False(ok)
+	_, ok = positions.Of(*slf4j)
+	a.False(ok, "only pointers are kept")
+
+	// Problems found by Validate can be pointed at
+	model, err := Unmarshal([]byte(positionsPom))
+	a.NoError(err)
+	problems := model.Validate(ValidationLevelStrict)
+	a.NotEmpty(problems)
+	for _, problem := range problems {
+		_, found := positions.Find(problem.Path)
+		a.NotEmpty(found, problem.Path)
+	}
+}
@@ -0,0 +1,488 @@
+package pom
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// ProfileContext describes the environment a build runs in, which decides
+// which profiles are active.  The OS values are compared the way Java reports
+// them in the os.name, os.arch and os.version system properties.
+type ProfileContext struct {
+	OSName    string
+	OSFamily  string
+	OSArch    string
+	OSVersion string
+	// JDKVersion is the java.version system property, such as 1.8.0_292 or 11.0.2
+	JDKVersion string
+	// SystemProperties are checked by property activation, like -D on the mvn command line
+	SystemProperties map[string]string
+	// BaseDir is the directory of the project, which file activation is relative to
+	BaseDir string
+
+	// EnabledProfiles and DisabledProfiles are profile ids switched on or off explicitly, like -P
+	EnabledProfiles  []string
+	DisabledProfiles []string
+}
+
+// NewProfileContext describes the running process as well as it can.
+// Environment variables become env.* system properties.  The JDK version is left empty.
+func NewProfileContext(baseDir string) *ProfileContext {
+	c := &ProfileContext{
+		OSArch:           runtime.GOARCH,
+		SystemProperties: make(map[string]string),
+		BaseDir:          baseDir,
+	}
+	switch runtime.GOOS {
+	case "windows":
+		c.OSName, c.OSFamily = "Windows", "windows"
+	case "darwin":
+		c.OSName, c.OSFamily = "Mac OS X", "mac"
+	case "linux":
+		c.OSName, c.OSFamily = "Linux", "unix"
+	case "freebsd":
+		c.OSName, c.OSFamily = "FreeBSD", "unix"
+	default:
+		c.OSName, c.OSFamily = runtime.GOOS, "unix"
+	}
+	switch runtime.GOARCH {
+	case "386":
+		c.OSArch = "x86"
+	case "arm64":
+		c.OSArch = "aarch64"
+	}
+	for _, variable := range os.Environ() {
+		if i := strings.Index(variable, "="); i > 0 {
+			c.SystemProperties["env."+variable[:i]] = variable[i+1:]
+		}
+	}
+	return c
+}
+
+// SetProfiles switches profiles on and off the way -P does: each id switches
+// a profile on, unless it starts with ! or -, which switches it off
+func (c *ProfileContext) SetProfiles(ids ...string) {
+	for _, id := range ids {
+		id = strings.TrimSpace(id)
+		switch {
+		case id == "":
+		case strings.HasPrefix(id, "!") || strings.HasPrefix(id, "-"):
+			c.DisabledProfiles = append(c.DisabledProfiles, id[1:])
+		default:
+			c.EnabledProfiles = append(c.EnabledProfiles, strings.TrimPrefix(id, "+"))
+		}
+	}
+}
+
+// ActiveProfiles returns the profiles of model that are active, in the order they are declared
+func (c *ProfileContext) ActiveProfiles(model Model) []*Profile {
+	if model.Profiles == nil {
+		return nil
+	}
+	return c.active(model.Profiles.Profile)
+}
+
+// active picks the active profiles out of the profiles of a single POM.
+// Profiles that are active by default are only used if nothing else is active.
+func (c *ProfileContext) active(profiles []*Profile) []*Profile {
+	active := make([]*Profile, 0)
+	defaults := make([]*Profile, 0)
+	for _, profile := range profiles {
+		id, _ := profile.GetID()
+		switch {
+		case containsString(c.DisabledProfiles, id):
+		case containsString(c.EnabledProfiles, id) || c.IsActive(profile):
+			active = append(active, profile)
+		case profile.Activation != nil && profile.Activation.ActiveByDefault != nil && *profile.Activation.ActiveByDefault:
+			defaults = append(defaults, profile)
+		}
+	}
+	if len(active) == 0 {
+		return defaults
+	}
+	return active
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// IsActive checks the activation conditions of profile, ignoring whether it
+// was explicitly switched on or off, or is active by default.
+// Every condition that is given has to match, and a profile without any conditions is not active.
+func (c *ProfileContext) IsActive(profile *Profile) bool {
+	activation := profile.Activation
+	if activation == nil {
+		return false
+	}
+	checked := false
+	if activation.Jdk != nil {
+		checked = true
+		if !c.jdkMatches(strings.TrimSpace(*activation.Jdk)) {
+			return false
+		}
+	}
+	if activation.Os != nil {
+		checked = true
+		if !c.osMatches(activation.Os) {
+			return false
+		}
+	}
+	if activation.Property != nil {
+		checked = true
+		if !c.propertyMatches(activation.Property) {
+			return false
+		}
+	}
+	if activation.File != nil {
+		checked = true
+		if !c.fileMatches(activation.File) {
+			return false
+		}
+	}
+	return checked
+}
+
+// jdkMatches checks a jdk condition, which is either a version prefix such as
+// 1.8 or !1.8, or a range such as [1.8,11)
+func (c *ProfileContext) jdkMatches(jdk string) bool {
+	if strings.HasPrefix(jdk, "[") || strings.HasPrefix(jdk, "(") {
+		return jdkInRange(c.JDKVersion, jdk)
+	}
+	if strings.HasPrefix(jdk, "!") {
+		return !strings.HasPrefix(c.JDKVersion, jdk[1:])
+	}
+	return strings.HasPrefix(c.JDKVersion, jdk)
+}
+
+// jdkBound is one end of a jdk range
+type jdkBound struct {
+	value  string
+	closed bool
+}
+
+// jdkInRange checks a JDK version against a range.  Maven only compares the first
+// three numbers of each version, so 1.8.0_292 is inside of (,1.8].
+func jdkInRange(version, spec string) bool {
+	bounds := make([]jdkBound, 0, 2)
+	tokens := strings.Split(spec, ",")
+	// Like Java's String.split, trailing empty tokens are dropped
+	for len(tokens) > 0 && tokens[len(tokens)-1] == "" {
+		tokens = tokens[:len(tokens)-1]
+	}
+	for _, token := range tokens {
+		token = strings.TrimSpace(token)
+		switch {
+		case strings.HasPrefix(token, "["):
+			bounds = append(bounds, jdkBound{strings.TrimPrefix(token, "["), true})
+		case strings.HasPrefix(token, "("):
+			bounds = append(bounds, jdkBound{strings.TrimPrefix(token, "("), false})
+		case strings.HasSuffix(token, "]"):
+			bounds = append(bounds, jdkBound{strings.TrimSuffix(token, "]"), true})
+		case strings.HasSuffix(token, ")"):
+			bounds = append(bounds, jdkBound{strings.TrimSuffix(token, ")"), false})
+		case token == "":
+			bounds = append(bounds, jdkBound{"", false})
+		}
+	}
+	if len(bounds) == 0 {
+		return false
+	}
+	if len(bounds) < 2 {
+		bounds = append(bounds, jdkBound{"99999999", false})
+	}
+
+	left := jdkRelation(version, bounds[0], true)
+	if left == 0 {
+		return true
+	}
+	if left < 0 {
+		return false
+	}
+	return jdkRelation(version, bounds[1], false) <= 0
+}
+
+var (
+	jdkIgnored   = regexp.MustCompile(`[^\d._-]`)
+	jdkSeparator = regexp.MustCompile(`[-_]`)
+)
+
+// jdkRelation compares version to a bound, returning where the version is relative to it
+func jdkRelation(version string, bound jdkBound, isLeft bool) int {
+	if bound.value == "" {
+		if isLeft {
+			return 1
+		}
+		return -1
+	}
+	version = jdkSeparator.ReplaceAllString(jdkIgnored.ReplaceAllString(version, ""), ".")
+	versionTokens := strings.Split(version, ".")
+	boundTokens := strings.Split(bound.value, ".")
+	for i := 0; i < 3; i++ {
+		x, y := 0, 0
+		if i < len(versionTokens) {
+			x, _ = strconv.Atoi(versionTokens[i])
+		}
+		if i < len(boundTokens) {
+			y, _ = strconv.Atoi(boundTokens[i])
+		}
+		if x < y {
+			return -1
+		} else if x > y {
+			return 1
+		}
+	}
+	if !bound.closed {
+		if isLeft {
+			return -1
+		}
+		return 1
+	}
+	return 0
+}
+
+// matchesNegatable compares two values ignoring case, where expected may start with ! to negate it
+func matchesNegatable(expected, actual string) bool {
+	if strings.HasPrefix(expected, "!") {
+		return !strings.EqualFold(expected[1:], actual)
+	}
+	return strings.EqualFold(expected, actual)
+}
+
+func (c *ProfileContext) osMatches(activation *ActivationOS) bool {
+	if family, ok := activation.GetFamily(); ok && family != "" {
+		negate := strings.HasPrefix(family, "!")
+		if c.isFamily(strings.TrimPrefix(family, "!")) == negate {
+			return false
+		}
+	}
+	if name, ok := activation.GetName(); ok && name != "" && !matchesNegatable(name, c.OSName) {
+		return false
+	}
+	if arch, ok := activation.GetArch(); ok && arch != "" && !matchesNegatable(arch, c.OSArch) {
+		return false
+	}
+	if version, ok := activation.GetVersion(); ok && version != "" && !matchesNegatable(version, c.OSVersion) {
+		return false
+	}
+	return true
+}
+
+// isFamily checks an OS family the way Maven does, which works the family out from the OS name.
+// The OSFamily of the context always matches as well.
+func (c *ProfileContext) isFamily(family string) bool {
+	family = strings.ToLower(family)
+	if c.OSFamily != "" && strings.EqualFold(c.OSFamily, family) {
+		return true
+	}
+	name := strings.ToLower(c.OSName)
+	windows := strings.Contains(name, "windows")
+	os2 := strings.Contains(name, "os/2")
+	netware := strings.Contains(name, "netware")
+	mac := strings.Contains(name, "mac")
+	openvms := strings.Contains(name, "openvms")
+	win9x := windows && (strings.Contains(name, "95") || strings.Contains(name, "98") || strings.Contains(name, "me") || strings.Contains(name, "ce"))
+	semicolonPaths := windows || os2 || netware || strings.Contains(name, "dos")
+	switch family {
+	case "windows":
+		return windows
+	case "os/2":
+		return os2
+	case "netware":
+		return netware
+	case "dos":
+		return semicolonPaths && !netware
+	case "mac":
+		return mac
+	case "tandem":
+		return strings.Contains(name, "nonstop_kernel")
+	case "unix":
+		return name != "" && !semicolonPaths && !openvms && (!mac || strings.HasSuffix(name, "x"))
+	case "win9x":
+		return win9x
+	case "winnt":
+		return windows && !win9x
+	case "z/os":
+		return strings.Contains(name, "z/os") || strings.Contains(name, "os/390")
+	case "os/400":
+		return strings.Contains(name, "os/400")
+	case "openvms":
+		return openvms
+	}
+	return false
+}
+
+// propertyMatches checks a property condition.  A name starting with ! means the
+// property must not be set, and a value starting with ! means it must not have that value.
+func (c *ProfileContext) propertyMatches(property *ActivationProperty) bool {
+	name, _ := property.GetName()
+	if name == "" {
+		return false
+	}
+	negateName := strings.HasPrefix(name, "!")
+	actual := c.SystemProperties[strings.TrimPrefix(name, "!")]
+	value, ok := property.GetValue()
+	if !ok || value == "" {
+		return (actual != "") != negateName
+	}
+	negateValue := strings.HasPrefix(value, "!")
+	return (strings.TrimPrefix(value, "!") == actual) != negateValue
+}
+
+// fileMatches checks a file condition.  exists wins if both exists and missing are given.
+// Paths can use ${basedir} and system properties, and are relative to BaseDir.
+func (c *ProfileContext) fileMatches(file *ActivationFile) bool {
+	path, missing := "", false
+	if exists, ok := file.GetExists(); ok && strings.TrimSpace(exists) != "" {
+		path = exists
+	} else if absent, ok := file.GetMissing(); ok && strings.TrimSpace(absent) != "" {
+		path, missing = absent, true
+	} else {
+		return false
+	}
+	interpolator := &Interpolator{BaseDir: c.BaseDir, SystemProperties: c.SystemProperties}
+	path, err := interpolator.Resolve(strings.TrimSpace(path))
+	if err != nil {
+		// Like Maven, a path that cannot be worked out never matches
+		return false
+	}
+	path = filepath.FromSlash(path)
+	if !filepath.IsAbs(path) && c.BaseDir != "" {
+		path = filepath.Join(c.BaseDir, path)
+	}
+	_, err = os.Stat(path)
+	return (err == nil) != missing
+}
+
+// Apply returns a copy of model with its active profiles merged into it
+func (c *ProfileContext) Apply(model Model) Model {
+	return ApplyProfiles(model, c.ActiveProfiles(model))
+}
+
+// ApplyProfiles returns a copy of model with profiles merged into it, in order,
+// the way Maven injects active profiles.  Anything a profile sets wins over the model.
+// model itself is not changed.
+func ApplyProfiles(model Model, profiles []*Profile) Model {
+	result := deepCopy(model).(Model)
+	for _, original := range profiles {
+		profile := deepCopy(*original).(Profile)
+		result.Modules = injectModules(result.Modules, profile.Modules)
+		result.Properties = mergeProperties(profile.Properties, result.Properties)
+		if profile.DependencyManagement != nil {
+			if result.DependencyManagement == nil {
+				result.DependencyManagement = &DependencyManagement{}
+			}
+			result.DependencyManagement.Dependencies = injectDependencies(profile.DependencyManagement.Dependencies, result.DependencyManagement.Dependencies)
+		}
+		result.Dependencies = injectDependencies(profile.Dependencies, result.Dependencies)
+		result.Repositories = mergeRepositories(profile.Repositories, result.Repositories)
+		result.PluginRepositories = mergePluginRepositories(profile.PluginRepositories, result.PluginRepositories)
+		if profile.DistributionManagement != nil {
+			if result.DistributionManagement == nil {
+				result.DistributionManagement = &DistributionManagement{}
+			}
+			overlay(result.DistributionManagement, profile.DistributionManagement)
+		}
+		if profile.Reporting != nil {
+			reporting := profile.Reporting
+			result.Reporting = mergeReporting(reporting, result.Reporting)
+		}
+		if profile.Build != nil {
+			result.Build = injectBuild(profile.Build, result.Build)
+		}
+	}
+	return result
+}
+
+func injectModules(modules, profile *SequenceModule) *SequenceModule {
+	if profile == nil || len(profile.Module) == 0 {
+		return modules
+	}
+	if modules == nil {
+		return profile
+	}
+	for _, module := range profile.Module {
+		found := false
+		for _, existing := range modules.Module {
+			if existing != nil && module != nil && *existing == *module {
+				found = true
+			}
+		}
+		if !found {
+			modules.Module = append(modules.Module, module)
+		}
+	}
+	return modules
+}
+
+// injectDependencies replaces the dependencies the profile redeclares where they
+// are, and adds the rest on the end
+func injectDependencies(profile, dependencies *SequenceDependency) *SequenceDependency {
+	if profile == nil || len(profile.Dependency) == 0 {
+		return dependencies
+	}
+	if dependencies == nil {
+		return profile
+	}
+	index := make(map[string]int)
+	for i, d := range dependencies.Dependency {
+		index[dependencyKey(d)] = i
+	}
+	for _, d := range profile.Dependency {
+		if i, ok := index[dependencyKey(d)]; ok {
+			dependencies.Dependency[i] = d
+		} else {
+			index[dependencyKey(d)] = len(dependencies.Dependency)
+			dependencies.Dependency = append(dependencies.Dependency, d)
+		}
+	}
+	return dependencies
+}
+
+// injectBuild merges the build section of a profile into the model's
+func injectBuild(profile *BuildBase, build *Build) *Build {
+	if build == nil {
+		build = &Build{}
+	}
+	build.DefaultGoal = firstString(profile.DefaultGoal, build.DefaultGoal)
+	build.Directory = firstString(profile.Directory, build.Directory)
+	build.FinalName = firstString(profile.FinalName, build.FinalName)
+	if profile.Resources != nil && len(profile.Resources.Resource) > 0 {
+		build.Resources = profile.Resources
+	}
+	if profile.TestResources != nil && len(profile.TestResources.TestResource) > 0 {
+		build.TestResources = profile.TestResources
+	}
+	build.Filters = mergeFilters(build.Filters, profile.Filters)
+	if profile.PluginManagement != nil {
+		if build.PluginManagement == nil {
+			build.PluginManagement = &PluginManagement{}
+		}
+		build.PluginManagement.Plugins = mergePlugins(profile.PluginManagement.Plugins, build.PluginManagement.Plugins, false)
+	}
+	build.Plugins = mergePlugins(profile.Plugins, build.Plugins, false)
+	return build
+}
+
+// overlay sets every field of dst that is set in src.  Both must be pointers to the same kind of struct.
+func overlay(dst, src interface{}) {
+	d := reflect.ValueOf(dst).Elem()
+	s := reflect.ValueOf(src).Elem()
+	for i := 0; i < s.NumField(); i++ {
+		field := s.Field(i)
+		if !d.Field(i).CanSet() || reflect.DeepEqual(field.Interface(), reflect.Zero(field.Type()).Interface()) {
+			continue
+		}
+		d.Field(i).Set(field)
+	}
+}
@@ -0,0 +1,189 @@
+package pom
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var profilePom = `<project>
+    <groupId>com.example</groupId>
+    <artifactId>profiles</artifactId>
+    <version>1</version>
+    <properties>
+        <env>dev</env>
+        <color>red</color>
+    </properties>
+    <dependencies>
+        <dependency><groupId>com.example</groupId><artifactId>a</artifactId><version>1</version></dependency>
+    </dependencies>
+    <build>
+        <plugins>
+            <plugin>
+                <artifactId>maven-compiler-plugin</artifactId>
+                <version>3.1</version>
+            </plugin>
+        </plugins>
+    </build>
+    <profiles>
+        <profile>
+            <id>default</id>
+            <activation><activeByDefault>true</activeByDefault></activation>
+            <properties><env>default</env></properties>
+        </profile>
+        <profile>
+            <id>java8</id>
+            <activation><jdk>[1.8,11)</jdk></activation>
+            <properties><env>java8</env></properties>
+        </profile>
+        <profile>
+            <id>not-java8</id>
+            <activation><jdk>!1.8</jdk></activation>
+        </profile>
+        <profile>
+            <id>linux-ci</id>
+            <activation>
+                <os><family>unix</family><arch>!x86</arch></os>
+                <property><name>ci</name></property>
+            </activation>
+            <dependencies>
+                <dependency><groupId>com.example</groupId><artifactId>a</artifactId><version>2</version></dependency>
+                <dependency><groupId>com.example</groupId><artifactId>b</artifactId><version>1</version></dependency>
+            </dependencies>
+            <build>
+                <plugins>
+                    <plugin>
+                        <artifactId>maven-compiler-plugin</artifactId>
+                        <inherited>false</inherited>
+                        <version>3.8.1</version>
+                    </plugin>
+                    <plugin>
+                        <artifactId>maven-surefire-plugin</artifactId>
+                    </plugin>
+                </plugins>
+            </build>
+        </profile>
+        <profile>
+            <id>not-release</id>
+            <activation><property><name>release</name><value>!true</value></property></activation>
+            <properties><color>blue</color></properties>
+        </profile>
+        <profile>
+            <id>generated</id>
+            <activation><file><exists>${basedir}/generated</exists></file></activation>
+            <modules><module>generated</module></modules>
+        </profile>
+    </profiles>
+</project>`
+
+func profileIDs(profiles []*Profile) []string {
+	ids := make([]string, 0, len(profiles))
+	for _, profile := range profiles {
+		id, _ := profile.GetID()
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func TestProfileActivation(t *testing.T) {
+	a := assert.New(t)
+	model, err := Unmarshal([]byte(profilePom))
+	a.NoError(err)
+	dir, err := ioutil.TempDir("", "pom")
+	a.NoError(err)
+	defer os.RemoveAll(dir)
+
+	c := &ProfileContext{
+		OSName:           "Linux",
+		OSArch:           "amd64",
+		JDKVersion:       "11.0.2",
+		SystemProperties: map[string]string{"release": "true"},
+		BaseDir:          dir,
+	}
+	a.Equal([]string{"not-java8"}, profileIDs(c.ActiveProfiles(model)))
+
+	c.JDKVersion = "1.8.0_292"
+	a.Equal([]string{"java8"}, profileIDs(c.ActiveProfiles(model)))
+
+	c.SystemProperties = map[string]string{"ci": "yes"}
+	a.Equal([]string{"java8", "linux-ci", "not-release"}, profileIDs(c.ActiveProfiles(model)))
+
+	c.OSArch = "x86"
+	a.Equal([]string{"java8", "not-release"}, profileIDs(c.ActiveProfiles(model)))
+
+	a.NoError(os.Mkdir(filepath.Join(dir, "generated"), 0755))
+	c.SetProfiles("!java8", "default")
+	a.Equal([]string{"default", "not-release", "generated"}, profileIDs(c.ActiveProfiles(model)))
+
+	// Nothing else is active, so the default profile is
+	c = &ProfileContext{JDKVersion: "11", SystemProperties: map[string]string{"release": "true"}}
+	c.SetProfiles("-not-java8")
+	a.Equal([]string{"default"}, profileIDs(c.ActiveProfiles(model)))
+}
+
+func TestProfileOSFamily(t *testing.T) {
+	a := assert.New(t)
+	families := map[string][]string{
+		"Linux":      {"unix"},
+		"Mac OS X":   {"mac", "unix"},
+		"Windows 10": {"windows", "winnt", "dos"},
+		"Windows 98": {"windows", "win9x", "dos"},
+	}
+	for name, expected := range families {
+		c := &ProfileContext{OSName: name}
+		for _, family := range []string{"unix", "mac", "windows", "winnt", "win9x", "dos", "os/2"} {
+			a.Equal(containsString(expected, family), c.isFamily(family), "%s %s", name, family)
+		}
+	}
+}
+
+func TestProfileJDKRanges(t *testing.T) {
+	a := assert.New(t)
+	ranges := []struct {
+		version string
+		spec    string
+		active  bool
+	}{
+		{"1.8.0_292", "[1.8,)", true},
+		{"1.8.0_292", "(,1.8]", true},
+		{"1.8.0_292", "(,1.8)", false},
+		{"1.7.0", "[1.8,)", false},
+		{"11.0.2", "[1.8,11)", false},
+		{"17", "(11,)", true},
+		{"11.0.2", "(11,)", true},
+		{"11", "(11,)", false},
+		{"9", "[1.8,11),[17,)", true},
+	}
+	for _, r := range ranges {
+		c := &ProfileContext{JDKVersion: r.version}
+		a.Equal(r.active, c.jdkMatches(r.spec), "%s in %s", r.version, r.spec)
+	}
+}
+
+func TestApplyProfiles(t *testing.T) {
+	a := assert.New(t)
+	model, err := Unmarshal([]byte(profilePom))
+	a.NoError(err)
+
+	c := &ProfileContext{OSName: "Linux", OSArch: "amd64", SystemProperties: map[string]string{"ci": "true"}}
+	c.SetProfiles("generated")
+	applied := c.Apply(model)
+
+	a.Equal("1", *model.Dependencies.Dependency[0].Version, "model is not changed")
+	a.Len(applied.Dependencies.Dependency, 2)
+	a.Equal("2", *applied.Dependencies.Dependency[0].Version)
+	a.Equal("b", *applied.Dependencies.Dependency[1].ArtifactID)
+
+	a.Len(applied.Build.Plugins.Plugin, 2)
+	a.Equal("3.8.1", *applied.Build.Plugins.Plugin[0].Version)
+	a.Equal("maven-surefire-plugin", *applied.Build.Plugins.Plugin[1].ArtifactID)
+
+	a.Equal([]XMLPropertiesEntry{
+		{XMLName: applied.Properties.Elements[0].XMLName, Value: "dev"},
+		{XMLName: applied.Properties.Elements[1].XMLName, Value: "blue"},
+	}, applied.Properties.Elements)
+	a.Equal("generated", *applied.Modules.Module[0])
+}
@@ -0,0 +1,144 @@
+package pom
+
+import (
+	"encoding/xml"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// Get returns the value of the property key.  If the property is written
+// more than once, the last one wins, the same as in Maven.
+func (m *XMLProperties) Get(key string) (string, bool) {
+	if m == nil {
+		return "", false
+	}
+	for i := len(m.Elements) - 1; i >= 0; i-- {
+		if m.Elements[i].XMLName.Local == key {
+			return m.Elements[i].Value, true
+		}
+	}
+	return "", false
+}
+
+// Set changes the value of the property key in place, keeping its comments,
+// or adds it to the end if there is no such property yet.
+// m can not be nil, so use Model.SetProperty if the model may not have any properties yet.
+// Usage:
+//   model.Properties.Set("project.build.sourceEncoding", "UTF-8")
+func (m *XMLProperties) Set(key, value string) {
+	for i := len(m.Elements) - 1; i >= 0; i-- {
+		if m.Elements[i].XMLName.Local == key {
+			m.Elements[i].Value = value
+			return
+		}
+	}
+	m.Elements = append(m.Elements, XMLPropertiesEntry{XMLName: xml.Name{Local: key}, Value: value})
+}
+
+// SetProperty sets the property key, adding the properties element if the model has none
+// Usage:
+//   var model pom.Model
+//   model.SetProperty("maven.compiler.release", "11")
+func (a *Model) SetProperty(key, value string) {
+	if a.Properties == nil {
+		a.Properties = &XMLProperties{}
+	}
+	a.Properties.Set(key, value)
+}
+
+// SetProperty sets the property key, adding the properties element if the profile has none
+func (a *Profile) SetProperty(key, value string) {
+	if a.Properties == nil {
+		a.Properties = &XMLProperties{}
+	}
+	a.Properties.Set(key, value)
+}
+
+// Delete removes the property key, along with its comments, and reports whether there was one
+func (m *XMLProperties) Delete(key string) bool {
+	if m == nil {
+		return false
+	}
+	kept := m.Elements[:0]
+	for _, entry := range m.Elements {
+		if entry.XMLName.Local != key {
+			kept = append(kept, entry)
+		}
+	}
+	deleted := len(kept) != len(m.Elements)
+	m.Elements = kept
+	return deleted
+}
+
+// Keys returns the name of every property, in the order they are written in
+func (m *XMLProperties) Keys() []string {
+	keys := make([]string, 0)
+	if m == nil {
+		return keys
+	}
+	seen := make(map[string]bool)
+	for _, entry := range m.Elements {
+		if !seen[entry.XMLName.Local] {
+			seen[entry.XMLName.Local] = true
+			keys = append(keys, entry.XMLName.Local)
+		}
+	}
+	return keys
+}
+
+// Range calls f with each property in the order of Keys, until f returns false
+// Usage:
+//   model.Properties.Range(func(key, value string) bool {
+//       fmt.Println(key, value)
+//       return true
+//   })
+func (m *XMLProperties) Range(f func(key, value string) bool) {
+	for _, key := range m.Keys() {
+		value, _ := m.Get(key)
+		if !f(key, value) {
+			return
+		}
+	}
+}
+
+// SortKeys puts the properties in alphabetical order.  Comments move along with their properties.
+func (m *XMLProperties) SortKeys() {
+	if m == nil {
+		return
+	}
+	sort.SliceStable(m.Elements, func(i, j int) bool {
+		return m.Elements[i].XMLName.Local < m.Elements[j].XMLName.Local
+	})
+}
+
+var (
+	commentType = reflect.TypeOf(xml.Comment{})
+	entryType   = reflect.TypeOf(xmlMapEntry{})
+)
+
+// layout returns the properties as a struct with a field for each comment and property, in order.
+// encoding/xml only indents comments that are struct fields, so this is how they get lines of their own.
+func (m XMLProperties) layout() interface{} {
+	fields := make([]reflect.StructField, 0)
+	values := make([]reflect.Value, 0)
+	add := func(field reflect.StructField, value interface{}) {
+		field.Name = "F" + strconv.Itoa(len(fields))
+		fields = append(fields, field)
+		values = append(values, reflect.ValueOf(value))
+	}
+	for _, entry := range m.Elements {
+		if len(entry.Comment) > 0 {
+			add(reflect.StructField{Type: commentType, Tag: `xml:",comment"`}, entry.Comment)
+		}
+		add(reflect.StructField{Type: entryType}, xmlMapEntry{XMLName: xml.Name{Local: entry.XMLName.Local}, Value: entry.Value})
+	}
+	if len(m.Comment) > 0 {
+		add(reflect.StructField{Type: commentType, Tag: `xml:",comment"`}, m.Comment)
+	}
+	layout := reflect.New(reflect.StructOf(fields)).Elem()
+	for i, value := range values {
+		layout.Field(i).Set(value)
+	}
+	return layout.Interface()
+}
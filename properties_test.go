@@ -0,0 +1,131 @@
+package pom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var propertiesPom = `<project>
+    <properties>
+        <project.build.sourceEncoding>UTF-8</project.build.sourceEncoding>
+        <!-- pinned until CVE-2019-12384 is fixed -->
+        <jackson.version>2.9.9</jackson.version>
+        <guava.version>28.0-jre</guava.version>
+        <guava.version>28.1-jre</guava.version>
+        <!-- trailing -->
+    </properties>
+</project>`
+
+func TestPropertiesMap(t *testing.T) {
+	a := assert.New(t)
+	model, err := Unmarshal([]byte(propertiesPom))
+	a.NoError(err)
+	properties := model.Properties
+
+	value, ok := properties.Get("project.build.sourceEncoding")
+	a.True(ok)
+	a.Equal("UTF-8", value)
+	value, _ = properties.Get("guava.version")
+	a.Equal("28.1-jre", value, "the last value wins")
+	_, ok = properties.Get("missing")
+	a.False(ok)
+	a.Equal([]string{"project.build.sourceEncoding", "jackson.version", "guava.version"}, properties.Keys())
+
+	properties.Set("jackson.version", "2.10.0")
+	properties.Set("java.version", "11")
+	a.Equal(" pinned until CVE-2019-12384 is fixed ", string(properties.Elements[1].Comment))
+	a.Equal("2.10.0", properties.Elements[1].Value)
+	a.Equal("java.version", properties.Elements[4].XMLName.Local)
+
+	a.True(properties.Delete("guava.version"))
+	a.False(properties.Delete("guava.version"))
+	ranged := make([]string, 0)
+	properties.Range(func(key, value string) bool {
+		ranged = append(ranged, key+"="+value)
+		return key != "jackson.version"
+	})
+	a.Equal([]string{"project.build.sourceEncoding=UTF-8", "jackson.version=2.10.0"}, ranged)
+
+	properties.SortKeys()
+	a.Equal([]string{"jackson.version", "java.version", "project.build.sourceEncoding"}, properties.Keys())
+	a.Equal(" pinned until CVE-2019-12384 is fixed ", string(properties.Elements[0].Comment))
+
+	var empty *XMLProperties
+	_, ok = empty.Get("anything")
+	a.False(ok)
+	a.Empty(empty.Keys())
+}
+
+func TestSetProperty(t *testing.T) {
+	a := assert.New(t)
+	var model Model
+	model.SetProperty("maven.compiler.release", "11")
+	model.SetProperty("maven.compiler.release", "17")
+	a.Equal([]string{"maven.compiler.release"}, model.Properties.Keys())
+	value, _ := model.Properties.Get("maven.compiler.release")
+	a.Equal("17", value)
+
+	var profile Profile
+	profile.SetProperty("skipTests", "true")
+	value, ok := profile.Properties.Get("skipTests")
+	a.True(ok)
+	a.Equal("true", value)
+}
+
+func TestPropertiesComments(t *testing.T) {
+	a := assert.New(t)
+	model, err := Unmarshal([]byte(propertiesPom))
+	a.NoError(err)
+	model.Properties.SortKeys()
+
+	data, err := Marshal(model)
+	a.NoError(err)
+	a.Contains(string(data), `
+    <properties>
+        <guava.version>28.0-jre</guava.version>
+        <guava.version>28.1-jre</guava.version>
+        <!-- pinned until CVE-2019-12384 is fixed -->
+        <jackson.version>2.9.9</jackson.version>
+        <project.build.sourceEncoding>UTF-8</project.build.sourceEncoding>
+        <!-- trailing -->
+    </properties>`)
+
+	again, err := Unmarshal(data)
+	a.NoError(err)
+	a.Equal(model.Properties.Keys(), again.Properties.Keys())
+	a.Equal(model.Properties.Comment, again.Properties.Comment)
+	for i := range model.Properties.Elements {
+		a.Equal(model.Properties.Elements[i].Comment, again.Properties.Elements[i].Comment)
+	}
+}
+
+func TestPropertiesCommentsNested(t *testing.T) {
+	a := assert.New(t)
+	model, err := Unmarshal([]byte(`<project>
+    <profiles>
+        <profile>
+            <id>ci</id>
+            <properties><!-- ci only --><skipTests>false</skipTests></properties>
+        </profile>
+    </profiles>
+    <build>
+        <plugins>
+            <plugin>
+                <artifactId>maven-surefire-plugin</artifactId>
+                <configuration><properties><!--keep--><a>b</a></properties></configuration>
+            </plugin>
+        </plugins>
+    </build>
+</project>`))
+	a.NoError(err)
+
+	data, err := Marshal(model)
+	a.NoError(err)
+	a.Contains(string(data), `
+            <properties>
+                <!-- ci only -->
+                <skipTests>false</skipTests>
+            </properties>`)
+	a.Contains(string(data), `<configuration><properties><!--keep--><a>b</a></properties></configuration>`)
+}
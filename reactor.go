@@ -0,0 +1,320 @@
+package pom
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ReactorProject is one project of a multi-module build
+type ReactorProject struct {
+	// Path is the pom.xml the project was read from
+	Path string
+	// Model is the effective model of the project, with its expressions resolved
+	Model Model
+
+	GroupID    string
+	ArtifactID string
+	Version    string
+
+	upstream   []*ReactorEdge
+	downstream []*ReactorEdge
+}
+
+// ID returns groupId:artifactId, which is unique within a reactor
+func (p *ReactorProject) ID() string {
+	return p.GroupID + ":" + p.ArtifactID
+}
+
+// String returns groupId:artifactId:version
+func (p *ReactorProject) String() string {
+	return p.ID() + ":" + p.Version
+}
+
+// Kinds of reference from one project of a reactor to another
+const (
+	ParentEdge     = "parent"
+	DependencyEdge = "dependency"
+	PluginEdge     = "plugin"
+	ExtensionEdge  = "extension"
+)
+
+// ReactorEdge is a reference from one project to another, which means To has to be built before From
+type ReactorEdge struct {
+	From *ReactorProject
+	To   *ReactorProject
+	Kind string
+}
+
+func (e *ReactorEdge) String() string {
+	return fmt.Sprintf("%s --%s--> %s", e.From.ID(), e.Kind, e.To.ID())
+}
+
+// Reactor is a multi-module build: a root project, and every module below it
+type Reactor struct {
+	// Projects are in the order they were found in, starting with the root
+	Projects []*ReactorProject
+	// Profiles decides which profiles are active.  Its BaseDir is set to each project's directory in turn.
+	Profiles ProfileContext
+	// Resolver finds parents that are not on disk.  It may be nil.
+	Resolver Resolver
+
+	byID map[string]*ReactorProject
+}
+
+// LoadReactor reads the POM at path, and every module below it, including
+// those listed in active profiles.  resolver finds parents that are outside
+// of the reactor, and may be nil if every parent is on disk.
+// activeProfiles switch profiles on, or off with a leading !, like -P does.
+func LoadReactor(path string, resolver Resolver, activeProfiles ...string) (*Reactor, error) {
+	profiles := ProfileContext{}
+	profiles.SetProfiles(activeProfiles...)
+	return LoadReactorWithProfiles(path, resolver, profiles)
+}
+
+// LoadReactorWithProfiles reads a reactor like LoadReactor, with profiles
+// activated by the environment profiles describes
+func LoadReactorWithProfiles(path string, resolver Resolver, profiles ProfileContext) (*Reactor, error) {
+	r := &Reactor{
+		Profiles: profiles,
+		Resolver: resolver,
+		byID:     make(map[string]*ReactorProject),
+	}
+	if err := r.load(path, nil); err != nil {
+		return nil, err
+	}
+	for _, project := range r.Projects {
+		r.link(project)
+	}
+	return r, nil
+}
+
+// Project returns the project with the groupId:artifactId id, or nil if it is not in the reactor
+func (r *Reactor) Project(id string) *ReactorProject {
+	return r.byID[id]
+}
+
+// load reads a project and its modules.  stack is the paths we are in the middle of loading.
+func (r *Reactor) load(path string, stack []string) error {
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		path = filepath.Join(path, "pom.xml")
+	}
+	path = filepath.Clean(path)
+	for _, loading := range stack {
+		if loading == path {
+			return fmt.Errorf("pom: module %s includes itself", path)
+		}
+	}
+
+	raw, err := ReadFile(path)
+	if err != nil {
+		return err
+	}
+	model, err := EffectiveFile(path, r.Resolver)
+	if err != nil {
+		return err
+	}
+	dir := filepath.Dir(path)
+	model = interpolateQuietly(model, dir)
+
+	project := &ReactorProject{Path: path, Model: model}
+	project.GroupID, _ = model.GetGroupID()
+	project.ArtifactID, _ = model.GetArtifactID()
+	project.Version, _ = model.GetVersion()
+	if existing, ok := r.byID[project.ID()]; ok {
+		return fmt.Errorf("pom: project %s is duplicated in the reactor, in %s and %s", project.ID(), existing.Path, path)
+	}
+	r.byID[project.ID()] = project
+	r.Projects = append(r.Projects, project)
+
+	// Modules are not inherited, so they come from the POM as it was written
+	modules := make([]*string, 0)
+	if raw.Modules != nil {
+		modules = append(modules, raw.Modules.Module...)
+	}
+	for _, profile := range r.profiles(dir).ActiveProfiles(raw) {
+		if profile.Modules != nil {
+			modules = append(modules, profile.Modules.Module...)
+		}
+	}
+	seen := make(map[string]bool)
+	for _, module := range modules {
+		if module == nil || seen[*module] {
+			continue
+		}
+		seen[*module] = true
+		if err := r.load(filepath.Join(dir, filepath.FromSlash(strings.TrimSpace(*module))), append(stack, path)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// profiles returns the profile context for the project in dir
+func (r *Reactor) profiles(dir string) *ProfileContext {
+	profiles := r.Profiles
+	profiles.BaseDir = dir
+	return &profiles
+}
+
+// link adds the edges from project to everything in the reactor it refers to
+func (r *Reactor) link(project *ReactorProject) {
+	model := r.profiles(filepath.Dir(project.Path)).Apply(project.Model)
+	if model.Parent != nil {
+		groupID, artifactID, version := parentCoordinates(*model.Parent)
+		r.addEdge(project, groupID, artifactID, version, ParentEdge)
+	}
+
+	dependencies := make([]*Dependency, 0)
+	plugins := make([]*Plugin, 0)
+	extensions := make([]*Extension, 0)
+	if model.Dependencies != nil {
+		dependencies = append(dependencies, model.Dependencies.Dependency...)
+	}
+	if model.Build != nil {
+		if model.Build.Plugins != nil {
+			plugins = append(plugins, model.Build.Plugins.Plugin...)
+		}
+		if model.Build.Extensions != nil {
+			extensions = append(extensions, model.Build.Extensions.Extension...)
+		}
+	}
+
+	for _, dependency := range dependencies {
+		groupID, _ := dependency.GetGroupID()
+		artifactID, _ := dependency.GetArtifactID()
+		version, _ := dependency.GetVersion()
+		r.addEdge(project, groupID, artifactID, version, DependencyEdge)
+	}
+	for _, plugin := range plugins {
+		groupID, ok := plugin.GetGroupID()
+		if !ok {
+			groupID = "org.apache.maven.plugins"
+		}
+		artifactID, _ := plugin.GetArtifactID()
+		version, _ := plugin.GetVersion()
+		r.addEdge(project, groupID, artifactID, version, PluginEdge)
+		// What a plugin depends on has to be built before the plugin can run
+		if plugin.Dependencies != nil {
+			for _, dependency := range plugin.Dependencies.Dependency {
+				groupID, _ := dependency.GetGroupID()
+				artifactID, _ := dependency.GetArtifactID()
+				version, _ := dependency.GetVersion()
+				r.addEdge(project, groupID, artifactID, version, PluginEdge)
+			}
+		}
+	}
+	for _, extension := range extensions {
+		groupID, _ := extension.GetGroupID()
+		artifactID, _ := extension.GetArtifactID()
+		version, _ := extension.GetVersion()
+		r.addEdge(project, groupID, artifactID, version, ExtensionEdge)
+	}
+}
+
+// addEdge records that from refers to groupID:artifactID:version, if that is in the reactor.
+// A version that does not match the project in the reactor refers to a released
+// artifact instead, so there is no edge.
+func (r *Reactor) addEdge(from *ReactorProject, groupID, artifactID, version, kind string) {
+	to, ok := r.byID[groupID+":"+artifactID]
+	if !ok || to == from {
+		return
+	}
+	if version != "" {
+		versionRange, err := ParseVersionRange(version)
+		if err != nil {
+			return
+		}
+		if versionRange.HasRestrictions() && !versionRange.Contains(ParseVersion(to.Version)) {
+			return
+		}
+		if !versionRange.HasRestrictions() && version != to.Version {
+			return
+		}
+	}
+	for _, existing := range from.upstream {
+		if existing.To == to {
+			return
+		}
+	}
+	edge := &ReactorEdge{From: from, To: to, Kind: kind}
+	from.upstream = append(from.upstream, edge)
+	to.downstream = append(to.downstream, edge)
+}
+
+// Upstream returns the edges to the projects that project refers to directly
+func (r *Reactor) Upstream(project *ReactorProject) []*ReactorEdge {
+	return project.upstream
+}
+
+// Downstream returns the edges from the projects that refer to project directly
+func (r *Reactor) Downstream(project *ReactorProject) []*ReactorEdge {
+	return project.downstream
+}
+
+// CycleError is returned when projects in a reactor refer to each other in a loop
+type CycleError struct {
+	// Edges go around the loop, so the last one leads back to the first project
+	Edges []*ReactorEdge
+}
+
+func (e *CycleError) Error() string {
+	path := make([]string, 0, len(e.Edges)+1)
+	for _, edge := range e.Edges {
+		path = append(path, edge.From.ID())
+	}
+	path = append(path, e.Edges[len(e.Edges)-1].To.ID())
+	return "pom: the projects in the reactor contain a cyclic reference: " + strings.Join(path, " --> ")
+}
+
+// BuildOrder sorts the projects so that every project comes after everything it refers to.
+// Apart from that, projects stay in the order they were found in, the same as Maven.
+func (r *Reactor) BuildOrder() ([]*ReactorProject, error) {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[*ReactorProject]int)
+	order := make([]*ReactorProject, 0, len(r.Projects))
+	path := make([]*ReactorEdge, 0)
+
+	var visit func(project *ReactorProject) error
+	visit = func(project *ReactorProject) error {
+		state[project] = visiting
+		for _, edge := range project.upstream {
+			switch state[edge.To] {
+			case visiting:
+				// The cycle starts where the path first reaches edge.To
+				start := len(path)
+				for i := range path {
+					if path[i].From == edge.To {
+						start = i
+						break
+					}
+				}
+				cycle := append(append([]*ReactorEdge{}, path[start:]...), edge)
+				return &CycleError{Edges: cycle}
+			case unvisited:
+				path = append(path, edge)
+				if err := visit(edge.To); err != nil {
+					return err
+				}
+				path = path[:len(path)-1]
+			}
+		}
+		state[project] = visited
+		order = append(order, project)
+		return nil
+	}
+
+	for _, project := range r.Projects {
+		if state[project] == unvisited {
+			if err := visit(project); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return order, nil
+}
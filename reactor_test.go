@@ -0,0 +1,149 @@
+package pom
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var reactorRootPom = `<project>
+    <groupId>com.example</groupId>
+    <artifactId>root</artifactId>
+    <version>1</version>
+    <packaging>pom</packaging>
+    <modules>
+        <module>app</module>
+        <module>core/pom.xml</module>
+    </modules>
+    <profiles>
+        <profile>
+            <id>extra</id>
+            <activation><activeByDefault>true</activeByDefault></activation>
+            <modules><module>tools</module></modules>
+        </profile>
+        <profile>
+            <id>plugins</id>
+            <modules><module>plugin</module></modules>
+        </profile>
+    </profiles>
+</project>`
+
+// reactorModulePom is a module of reactorRootPom, with the given extra content
+func reactorModulePom(artifactID, content string) string {
+	return fmt.Sprintf(`<project>
+    <parent>
+        <groupId>com.example</groupId>
+        <artifactId>root</artifactId>
+        <version>1</version>
+    </parent>
+    <artifactId>%s</artifactId>
+    %s
+</project>`, artifactID, content)
+}
+
+func writeReactor(dir string, poms map[string]string) error {
+	for path, pom := range poms {
+		path = filepath.Join(dir, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(path, []byte(pom), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func reactorIDs(projects []*ReactorProject) []string {
+	ids := make([]string, 0, len(projects))
+	for _, project := range projects {
+		ids = append(ids, project.ArtifactID)
+	}
+	return ids
+}
+
+func TestReactor(t *testing.T) {
+	a := assert.New(t)
+	dir, err := ioutil.TempDir("", "pom")
+	a.NoError(err)
+	defer os.RemoveAll(dir)
+	a.NoError(writeReactor(dir, map[string]string{
+		"pom.xml":      reactorRootPom,
+		"core/pom.xml": reactorModulePom("core", ""),
+		"app/pom.xml": reactorModulePom("app", `<dependencies>
+        <dependency><groupId>${project.groupId}</groupId><artifactId>core</artifactId><version>${project.version}</version></dependency>
+        <dependency><groupId>junit</groupId><artifactId>junit</artifactId><version>4.12</version></dependency>
+    </dependencies>`),
+		"tools/pom.xml": reactorModulePom("tools", `<dependencies>
+        <dependency><groupId>com.example</groupId><artifactId>app</artifactId><version>[1,2)</version></dependency>
+        <dependency><groupId>com.example</groupId><artifactId>core</artifactId><version>0.9</version></dependency>
+    </dependencies>
+    <build><plugins><plugin><groupId>com.example</groupId><artifactId>plugin</artifactId><version>1</version></plugin></plugins></build>`),
+		"plugin/pom.xml": reactorModulePom("plugin", `<packaging>maven-plugin</packaging>
+    <build><extensions><extension><groupId>com.example</groupId><artifactId>core</artifactId><version>1</version></extension></extensions></build>`),
+	}))
+
+	r, err := LoadReactor(dir, nil)
+	a.NoError(err)
+	a.Equal([]string{"root", "app", "core", "tools"}, reactorIDs(r.Projects))
+	order, err := r.BuildOrder()
+	a.NoError(err)
+	a.Equal([]string{"root", "core", "app", "tools"}, reactorIDs(order))
+
+	tools := r.Project("com.example:tools")
+	a.Equal("com.example:tools:1", tools.String())
+	edges := make([]string, 0)
+	for _, edge := range r.Upstream(tools) {
+		edges = append(edges, edge.String())
+	}
+	// core 0.9 is a release, not the core in the reactor
+	a.Equal([]string{"com.example:tools --parent--> com.example:root", "com.example:tools --dependency--> com.example:app"}, edges)
+	a.Len(r.Downstream(r.Project("com.example:core")), 1)
+
+	// Switching on another profile switches off the one that is active by default
+	r, err = LoadReactor(filepath.Join(dir, "pom.xml"), nil, "plugins")
+	a.NoError(err)
+	a.Equal([]string{"root", "app", "core", "plugin"}, reactorIDs(r.Projects))
+
+	r, err = LoadReactor(filepath.Join(dir, "pom.xml"), nil, "plugins", "extra")
+	a.NoError(err)
+	order, err = r.BuildOrder()
+	a.NoError(err)
+	a.Equal([]string{"root", "core", "app", "plugin", "tools"}, reactorIDs(order))
+	a.Equal(ExtensionEdge, r.Upstream(r.Project("com.example:plugin"))[1].Kind)
+}
+
+func TestReactorCycle(t *testing.T) {
+	a := assert.New(t)
+	dir, err := ioutil.TempDir("", "pom")
+	a.NoError(err)
+	defer os.RemoveAll(dir)
+	a.NoError(writeReactor(dir, map[string]string{
+		"pom.xml": reactorRootPom,
+		"core/pom.xml": reactorModulePom("core", `<dependencies>
+        <dependency><groupId>com.example</groupId><artifactId>tools</artifactId><version>1</version></dependency>
+    </dependencies>`),
+		"app/pom.xml": reactorModulePom("app", `<dependencies>
+        <dependency><groupId>com.example</groupId><artifactId>core</artifactId><version>1</version></dependency>
+    </dependencies>`),
+		"tools/pom.xml": reactorModulePom("tools", `<dependencies>
+        <dependency><groupId>com.example</groupId><artifactId>app</artifactId><version>1</version></dependency>
+    </dependencies>`),
+	}))
+
+	r, err := LoadReactor(dir, nil)
+	a.NoError(err)
+	_, err = r.BuildOrder()
+	a.EqualError(err, "pom: the projects in the reactor contain a cyclic reference: com.example:app --> com.example:core --> com.example:tools --> com.example:app")
+	cycle, ok := err.(*CycleError)
+	a.True(ok)
+	a.Len(cycle.Edges, 3)
+
+	a.NoError(writeReactor(dir, map[string]string{"app/pom.xml": reactorModulePom("core", "")}))
+	_, err = LoadReactor(dir, nil)
+	a.Error(err)
+}
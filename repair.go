@@ -0,0 +1,231 @@
+package pom
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"strings"
+)
+
+const pomNamespace = "http://maven.apache.org/POM/4.0.0"
+
+// maxRepairs stops Repair from going on forever on something that is not XML at all
+const maxRepairs = 1000
+
+// RepairedError is a problem Repair found and fixed
+type RepairedError struct {
+	Location
+	Msg string
+}
+
+func (e *RepairedError) Error() string {
+	return "pom: " + e.Location.String() + ": " + e.Msg
+}
+
+// UnmarshalLenient reads as much of rawPom as it can, even if it is not well formed.
+// Everything that had to be fixed to read it is returned as RepairedErrors, and the error
+// is only set if it could not be read at all.  Use Repair to get the fixed POM itself.
+func UnmarshalLenient(rawPom []byte) (Model, Errors, error) {
+	repaired, problems, err := Repair(rawPom)
+	if err != nil {
+		return Model{}, problems, err
+	}
+	model, err := Unmarshal(repaired)
+	return model, problems, err
+}
+
+// Repair fixes the problems that stop older POMs from being read, and returns the fixed POM
+// along with a RepairedError for each fix, located in rawPom.  The fixed POM is in the same charset as rawPom.
+// What can be fixed is:
+//   - a byte order mark, which is removed
+//   - a stray &, which is escaped
+//   - end elements that are missing, or that do not match anything
+//   - a POM that stops part of the way through
+//   - anything after </project>
+//   - no namespace on the project element
+// Usage:
+//   repaired, problems, err := pom.Repair(raw)
+//   for _, problem := range problems {
+//       log.Println(problem)
+//   }
+//   ioutil.WriteFile("pom.xml", repaired, 0644)
+func Repair(rawPom []byte) ([]byte, Errors, error) {
+	data, charset, err := toUTF8(rawPom)
+	if err != nil {
+		return nil, nil, err
+	}
+	r := &repairer{lines: lineOffsets(data), raw: append([]byte{}, data...)}
+	if bytes.HasPrefix(r.raw, []byte("\xef\xbb\xbf")) {
+		r.fixed(0, "", "removed the byte order mark")
+		r.edit(0, 3, "")
+	}
+	for i := 0; ; i++ {
+		tree, err := parseTree(r.raw)
+		if err == nil {
+			if firstElement(tree) == nil {
+				return nil, r.problems, errors.New("pom: document has no project element")
+			}
+			r.finish(tree)
+			repaired, err := fromUTF8(r.raw, charset)
+			return repaired, r.problems, err
+		}
+		syntaxErr, ok := err.(*SyntaxError)
+		if !ok || i == maxRepairs || !r.repair(syntaxErr) {
+			return nil, r.problems, err
+		}
+	}
+}
+
+type repairer struct {
+	lines    []int
+	raw      []byte
+	edits    []repairEdit
+	problems Errors
+}
+
+// repairEdit is raw[start:start+removed] being replaced with inserted bytes
+type repairEdit struct {
+	start, removed, inserted int
+}
+
+// fixed records a fix made at offset in raw
+func (r *repairer) fixed(offset int, path, msg string) {
+	// Undo the edits made so far to find where offset was in the original
+	for i := len(r.edits) - 1; i >= 0; i-- {
+		e := r.edits[i]
+		if offset >= e.start+e.inserted {
+			offset += e.removed - e.inserted
+		} else if offset > e.start {
+			offset = e.start
+		}
+	}
+	line, column := lineColumn(r.lines, offset)
+	r.problems = append(r.problems, &RepairedError{Location: Location{Line: line, Column: column, Path: path}, Msg: msg})
+}
+
+// edit replaces raw[start:end] with text
+func (r *repairer) edit(start, end int, text string) {
+	r.raw = append(r.raw[:start:start], append([]byte(text), r.raw[end:]...)...)
+	r.edits = append(r.edits, repairEdit{start: start, removed: end - start, inserted: len(text)})
+}
+
+// repair fixes the syntax error err, and reports whether it could
+func (r *repairer) repair(err *SyntaxError) bool {
+	offset := lineOffsets(r.raw)[err.Line-1] + err.Column - 1
+	open, _, rootEnd := openElements(r.raw[:offset])
+	if rootEnd >= 0 {
+		r.fixed(rootEnd, "", "removed everything after the project element")
+		r.edit(rootEnd, len(r.raw), "\n")
+		return true
+	}
+	switch {
+	case strings.HasPrefix(err.Msg, "invalid character entity"):
+		i := bytes.LastIndexByte(r.raw[:offset], '&')
+		if i < 0 {
+			return false
+		}
+		r.fixed(i, err.Path, "escaped a stray & as &amp;")
+		r.edit(i, i+1, "&amp;")
+	case strings.HasPrefix(err.Msg, "unexpected end element </"):
+		name := strings.TrimSuffix(strings.TrimPrefix(err.Msg, "unexpected end element </"), ">")
+		depth := -1
+		for i := len(open) - 1; i >= 0; i-- {
+			if qualifiedName(open[i]) == name {
+				depth = i
+				break
+			}
+		}
+		if depth < 0 {
+			end := bytes.IndexByte(r.raw[offset:], '>')
+			if end < 0 {
+				return false
+			}
+			r.fixed(offset, err.Path, "removed the end element </"+name+"> that does not match anything")
+			r.edit(offset, offset+end+1, "")
+			return true
+		}
+		r.close(offset, open, depth+1)
+	case err.Msg == "unexpected EOF":
+		open, good, _ := openElements(r.raw)
+		if strings.TrimSpace(string(r.raw[good:])) != "" {
+			r.fixed(good, "", "removed the unfinished markup at the end")
+			r.edit(good, len(r.raw), "")
+		}
+		if len(open) == 0 {
+			return false
+		}
+		r.close(len(r.raw), open, 0)
+	default:
+		return false
+	}
+	return true
+}
+
+// close adds end elements at offset for the elements in open from index from on, innermost first
+func (r *repairer) close(offset int, open []xml.Name, from int) {
+	var closers strings.Builder
+	path := make([]string, 0, len(open))
+	for _, name := range open {
+		path = append(path, name.Local)
+	}
+	for i := len(open) - 1; i >= from; i-- {
+		r.fixed(offset, strings.Join(path[:i+1], "/"), "added the missing end element </"+qualifiedName(open[i])+">")
+		closers.WriteString("</" + qualifiedName(open[i]) + ">")
+	}
+	r.edit(offset, offset, closers.String())
+}
+
+// finish fixes anything left once the POM can be read
+func (r *repairer) finish(tree *node) {
+	root := firstElement(tree)
+	end := root.end
+	namespaced := false
+	for _, attr := range root.attrs {
+		if attr.Name.Space == "" && attr.Name.Local == "xmlns" {
+			namespaced = true
+		}
+	}
+	if !namespaced {
+		at := root.start + len("<"+qualifiedName(root.name))
+		namespace := ` xmlns="` + pomNamespace + `"`
+		r.fixed(at, root.name.Local, "added the POM namespace")
+		r.edit(at, at, namespace)
+		end += len(namespace)
+	}
+	after := false
+	for _, c := range tree.children {
+		if c == root {
+			after = true
+		} else if after && (c.kind == elementNode || (c.kind == textNode && strings.TrimSpace(c.text) != "")) {
+			r.fixed(end, "", "removed everything after the project element")
+			r.edit(end, len(r.raw), "\n")
+			break
+		}
+	}
+}
+
+// openElements reads raw as far as it can.  It returns the elements left open, the offset
+// the last complete token ended at, and the offset the root element ended at, or -1 if it did not.
+func openElements(raw []byte) ([]xml.Name, int, int) {
+	d := xml.NewDecoder(bytes.NewReader(raw))
+	open := make([]xml.Name, 0)
+	good, rootEnd := 0, -1
+	for {
+		tok, err := d.RawToken()
+		if err != nil {
+			return open, good, rootEnd
+		}
+		good = int(d.InputOffset())
+		switch t := tok.(type) {
+		case xml.StartElement:
+			open = append(open, t.Name)
+		case xml.EndElement:
+			if len(open) > 0 {
+				open = open[:len(open)-1]
+				if len(open) == 0 && rootEnd < 0 {
+					rootEnd = good
+				}
+			}
+		}
+	}
+}
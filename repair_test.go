@@ -0,0 +1,70 @@
+package pom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepair(t *testing.T) {
+	a := assert.New(t)
+	broken := "\xef\xbb\xbf<project>\n" +
+		"    <name>Tom & Jerry</name>\n" +
+		"    <dependencies>\n" +
+		"        <dependency>\n" +
+		"            <artifactId>a</artifactId>\n" +
+		"    </dependencies>\n" +
+		"    <url>http://example.com/?a=1&b=2</url></build>\n" +
+		"</project>\n" +
+		"garbage</foo>\n"
+
+	repaired, problems, err := Repair([]byte(broken))
+	a.NoError(err)
+	a.Equal(`<project xmlns="http://maven.apache.org/POM/4.0.0">
+    <name>Tom &amp; Jerry</name>
+    <dependencies>
+        <dependency>
+            <artifactId>a</artifactId>
+    </dependency></dependencies>
+    <url>http://example.com/?a=1&amp;b=2</url>
+</project>
+`, string(repaired))
+	a.Equal("pom: 1:1: removed the byte order mark\n"+
+		"pom: 2:15: project/name: escaped a stray & as &amp;\n"+
+		"pom: 6:5: project/dependencies/dependency: added the missing end element </dependency>\n"+
+		"pom: 7:33: project/url: escaped a stray & as &amp;\n"+
+		"pom: 7:43: project: removed the end element </build> that does not match anything\n"+
+		"pom: 8:11: removed everything after the project element\n"+
+		"pom: 1:12: project: added the POM namespace", problems.Error())
+
+	_, problems, err = Repair([]byte("<project><name>a</name></project><x/>"))
+	a.NoError(err)
+	a.Len(problems, 2)
+	a.IsType(&RepairedError{}, problems[0])
+
+	repaired, problems, err = Repair([]byte(`<project xmlns="http://maven.apache.org/POM/4.0.0"><dependencies><dependency><artifactId>a</art`))
+	a.NoError(err)
+	a.Equal(`<project xmlns="http://maven.apache.org/POM/4.0.0"><dependencies><dependency><artifactId>a</artifactId></dependency></dependencies></project>`, string(repaired))
+	a.Equal("pom: 1:91: removed the unfinished markup at the end", problems[0].Error())
+	a.Len(problems, 5)
+
+	_, _, err = Repair([]byte("not a pom"))
+	a.EqualError(err, "pom: document has no project element")
+	_, _, err = Repair([]byte("<project><name>a</name><</project>"))
+	a.Error(err, "not everything can be fixed")
+}
+
+func TestUnmarshalLenient(t *testing.T) {
+	a := assert.New(t)
+	model, problems, err := UnmarshalLenient([]byte("<project>\n  <groupId>com.example</groupId>\n  <name>R&D tools</name>\n  <version>1</project>"))
+	a.NoError(err)
+	a.Len(problems, 3)
+	a.Equal("com.example", *model.GroupID)
+	a.Equal("R&D tools", *model.Name)
+	a.Equal("1", *model.Version)
+
+	model, problems, err = UnmarshalLenient([]byte(examplePom))
+	a.NoError(err)
+	a.Empty(problems)
+	a.NotNil(model.Build)
+}
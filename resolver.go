@@ -0,0 +1,84 @@
+package pom
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Resolver finds the POM for a set of coordinates, such as the parent of a project
+type Resolver interface {
+	Resolve(groupID, artifactID, version string) (Model, error)
+}
+
+// NotFoundError is returned by a Resolver when it does not have the POM that was asked for
+type NotFoundError struct {
+	GroupID    string
+	ArtifactID string
+	Version    string
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("pom: could not find %s:%s:%s", e.GroupID, e.ArtifactID, e.Version)
+}
+
+// VersionLister is implemented by resolvers that can list the versions they have of an artifact.
+// It is needed to pick versions for dependencies with version ranges.
+type VersionLister interface {
+	Versions(groupID, artifactID string) ([]string, error)
+}
+
+// RepositoryResolver finds POMs in a directory laid out like a Maven repository,
+// such as ~/.m2/repository
+type RepositoryResolver struct {
+	Dir string
+}
+
+// Resolve reads {Dir}/group/path/artifactId/version/artifactId-version.pom
+func (r RepositoryResolver) Resolve(groupID, artifactID, version string) (Model, error) {
+	c := Coordinates{GroupID: groupID, ArtifactID: artifactID, Version: version, Type: "pom"}
+	path := filepath.Join(r.Dir, filepath.FromSlash(c.Path()))
+	model, err := ReadFile(path)
+	if os.IsNotExist(err) {
+		return model, &NotFoundError{GroupID: groupID, ArtifactID: artifactID, Version: version}
+	}
+	return model, err
+}
+
+// Versions lists every version of an artifact that has a POM in the repository
+func (r RepositoryResolver) Versions(groupID, artifactID string) ([]string, error) {
+	dir := r.artifactDir(groupID, artifactID)
+	entries, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	versions := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(dir, entry.Name(), artifactID+"-"+entry.Name()+".pom")); err == nil {
+			versions = append(versions, entry.Name())
+		}
+	}
+	return versions, nil
+}
+
+// artifactDir is the directory holding every version of an artifact
+func (r RepositoryResolver) artifactDir(groupID, artifactID string) string {
+	return filepath.Join(r.Dir, filepath.FromSlash(strings.Replace(groupID, ".", "/", -1)), artifactID)
+}
+
+// ReadFile reads the POM at path
+func ReadFile(path string) (Model, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return Model{}, err
+	}
+	return Unmarshal(data)
+}
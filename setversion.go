@@ -0,0 +1,316 @@
+package pom
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+)
+
+// VersionChange is a single value changed by SetVersion
+type VersionChange struct {
+	// Path is the pom.xml the change is in
+	Path string
+	// Location is where in the POM the value is, such as project/parent/version.
+	// It is a path Positions.Lookup can find.
+	Location string
+	OldValue string
+	NewValue string
+}
+
+// VersionUpdate is the result of SetVersion.  Nothing is written until Write is called.
+type VersionUpdate struct {
+	Changes []VersionChange
+
+	documents map[string]*Document
+}
+
+// SetVersion changes the version of the project groupID:artifactID to newVersion,
+// along with everything in the reactor that refers to it, like mvn versions:set does:
+//   - the version of the project itself, or of the parent it inherits its version from
+//   - the parent version of every child, and the version of children that inherit it
+//   - the version of dependencies, dependencyManagement, plugins and extensions that refer to a changed project
+//   - properties that hold the version, when a reference uses one, or when a version is
+//     given by one, like ${revision}.  Versions given by any other expression are an error.
+// The files keep their formatting, and are only written by VersionUpdate.Write.
+func (r *Reactor) SetVersion(groupID, artifactID, newVersion string) (*VersionUpdate, error) {
+	project := r.Project(groupID + ":" + artifactID)
+	if project == nil {
+		return nil, fmt.Errorf("pom: %s:%s is not in the reactor", groupID, artifactID)
+	}
+	u := &VersionUpdate{documents: make(map[string]*Document)}
+	for _, p := range r.Projects {
+		data, err := ioutil.ReadFile(p.Path)
+		if err != nil {
+			return nil, err
+		}
+		doc, err := UnmarshalDocument(data)
+		if err != nil {
+			return nil, fmt.Errorf("pom: %s: %v", p.Path, err)
+		}
+		u.documents[p.Path] = doc
+	}
+
+	// A project that inherits its version can only change it by changing its parent's
+	for u.documents[project.Path].Model.Version == nil && project.Model.Parent != nil {
+		parentGroupID, parentArtifactID, parentVersion := parentCoordinates(*project.Model.Parent)
+		parent := r.Project(parentGroupID + ":" + parentArtifactID)
+		if parent == nil || parent.Version != parentVersion {
+			return nil, fmt.Errorf("pom: %s inherits its version from %s:%s, which is not in the reactor", project.ID(), parentGroupID, parentArtifactID)
+		}
+		project = parent
+	}
+	oldVersion := project.Version
+	if oldVersion == newVersion {
+		return u, nil
+	}
+
+	// Work out every project whose version changes, following children that inherit the version
+	changed := map[string]bool{project.ID(): true}
+	reparented := make(map[string]bool)
+	if err := u.setVersion(r, project, "project/version", &u.documents[project.Path].Model.Version, oldVersion, newVersion); err != nil {
+		return nil, err
+	}
+	for found := true; found; {
+		found = false
+		for _, p := range r.Projects {
+			doc := u.documents[p.Path]
+			if doc.Model.Parent == nil || reparented[p.ID()] {
+				continue
+			}
+			parentGroupID, parentArtifactID, parentVersion := parentCoordinates(*p.Model.Parent)
+			if !changed[parentGroupID+":"+parentArtifactID] || parentVersion != oldVersion {
+				continue
+			}
+			if err := u.setVersion(r, p, "project/parent/version", &doc.Model.Parent.Version, oldVersion, newVersion); err != nil {
+				return nil, err
+			}
+			reparented[p.ID()] = true
+			inherited := doc.Model.Version == nil
+			if !inherited && p.Version == oldVersion {
+				// A child that repeats the version of its parent moves along with it
+				if err := u.setVersion(r, p, "project/version", &doc.Model.Version, oldVersion, newVersion); err != nil {
+					return nil, err
+				}
+				inherited = true
+			}
+			if inherited {
+				changed[p.ID()] = true
+				found = true
+			}
+		}
+	}
+
+	for _, p := range r.Projects {
+		u.updateReferences(r, p, changed, oldVersion, newVersion)
+	}
+	return u, nil
+}
+
+// setVersion changes the version at location in the POM of project.  A version that comes
+// from a property, like ${revision}, has the property changed instead, and any other
+// expression is an error, as there is no telling what to change.
+func (u *VersionUpdate) setVersion(r *Reactor, project *ReactorProject, location string, value **string, oldVersion, newVersion string) error {
+	if *value == nil || !strings.Contains(**value, "${") {
+		u.set(project.Path, location, value, oldVersion, newVersion)
+		return nil
+	}
+	if versionExpressions[**value] || u.updateProperty(r, project, **value, oldVersion, newVersion) {
+		return nil
+	}
+	return fmt.Errorf("pom: %s: cannot change %s from %s, as it comes from %s", project.Path, location, oldVersion, **value)
+}
+
+// set changes a value, and records the change
+func (u *VersionUpdate) set(path, location string, value **string, oldValue, newValue string) {
+	v := newValue
+	*value = &v
+	u.Changes = append(u.Changes, VersionChange{Path: path, Location: location, OldValue: oldValue, NewValue: newValue})
+}
+
+// updateReferences changes the versions of anything in project that refers to a changed project
+func (u *VersionUpdate) updateReferences(r *Reactor, project *ReactorProject, changed map[string]bool, oldVersion, newVersion string) {
+	model := &u.documents[project.Path].Model
+	interpolator := &Interpolator{Model: project.Model, SystemProperties: map[string]string{}}
+	matches := func(groupID, artifactID *string) bool {
+		if groupID == nil || artifactID == nil {
+			return false
+		}
+		g, _ := interpolator.Resolve(*groupID)
+		a, _ := interpolator.Resolve(*artifactID)
+		return changed[g+":"+a]
+	}
+	reference := func(location string, version **string) {
+		if *version == nil {
+			return
+		}
+		if **version == oldVersion {
+			u.set(project.Path, location, version, oldVersion, newVersion)
+			return
+		}
+		u.updateProperty(r, project, **version, oldVersion, newVersion)
+	}
+	dependencies := func(location string, dependencies *SequenceDependency) {
+		if dependencies == nil {
+			return
+		}
+		for _, d := range dependencies.Dependency {
+			if matches(d.GroupID, d.ArtifactID) {
+				reference(fmt.Sprintf("%s/dependency[%s]/version", location, strings.TrimSuffix(dependencyKey(d), ":")), &d.Version)
+			}
+		}
+	}
+	plugins := func(location string, plugins *SequencePlugin) {
+		if plugins == nil {
+			return
+		}
+		for _, p := range plugins.Plugin {
+			groupID := p.GroupID
+			if groupID == nil {
+				defaultGroupID := "org.apache.maven.plugins"
+				groupID = &defaultGroupID
+			}
+			if matches(groupID, p.ArtifactID) {
+				reference(fmt.Sprintf("%s/plugin[%s]/version", location, pluginKey(p.GroupID, p.ArtifactID)), &p.Version)
+			}
+			if p.ArtifactID != nil {
+				dependencies(fmt.Sprintf("%s/plugin[%s]/dependencies", location, pluginKey(p.GroupID, p.ArtifactID)), p.Dependencies)
+			}
+		}
+	}
+	build := func(location string, b *BuildBase) {
+		if b == nil {
+			return
+		}
+		plugins(location+"/plugins", b.Plugins)
+		if b.PluginManagement != nil {
+			plugins(location+"/pluginManagement/plugins", b.PluginManagement.Plugins)
+		}
+	}
+
+	dependencies("project/dependencies", model.Dependencies)
+	if model.DependencyManagement != nil {
+		dependencies("project/dependencyManagement/dependencies", model.DependencyManagement.Dependencies)
+	}
+	if model.Build != nil {
+		build("project/build", &BuildBase{Plugins: model.Build.Plugins, PluginManagement: model.Build.PluginManagement})
+		if model.Build.Extensions != nil {
+			for i, e := range model.Build.Extensions.Extension {
+				// Extensions have no key to be looked up by, so they go by their index
+				if matches(e.GroupID, e.ArtifactID) {
+					reference(fmt.Sprintf("project/build/extensions/extension[%d]/version", i), &e.Version)
+				}
+			}
+		}
+	}
+	if model.Profiles != nil {
+		for _, profile := range model.Profiles.Profile {
+			id, _ := profile.GetID()
+			location := fmt.Sprintf("project/profiles/profile[%s]", id)
+			dependencies(location+"/dependencies", profile.Dependencies)
+			if profile.DependencyManagement != nil {
+				dependencies(location+"/dependencyManagement/dependencies", profile.DependencyManagement.Dependencies)
+			}
+			build(location+"/build", profile.Build)
+		}
+	}
+}
+
+// versionExpressions follow the project's own version, so they never need changing
+var versionExpressions = map[string]bool{
+	"${project.version}":        true,
+	"${pom.version}":            true,
+	"${version}":                true,
+	"${project.parent.version}": true,
+}
+
+// updateProperty changes the property that value refers to, if it holds oldVersion.
+// The property is looked for in project, and then in its parents in the reactor.
+// It reports whether the property now holds newVersion.
+func (u *VersionUpdate) updateProperty(r *Reactor, project *ReactorProject, value, oldVersion, newVersion string) bool {
+	if versionExpressions[value] || !strings.HasPrefix(value, "${") || !strings.HasSuffix(value, "}") {
+		return false
+	}
+	name := value[2 : len(value)-1]
+	for p := project; p != nil; {
+		doc := u.documents[p.Path]
+		if doc.Model.Properties != nil {
+			// The last declaration is the one in use
+			for i := len(doc.Model.Properties.Elements) - 1; i >= 0; i-- {
+				entry := &doc.Model.Properties.Elements[i]
+				if entry.XMLName.Local != name {
+					continue
+				}
+				if entry.Value == oldVersion {
+					entry.Value = newVersion
+					u.Changes = append(u.Changes, VersionChange{Path: p.Path, Location: "project/properties/" + name, OldValue: oldVersion, NewValue: newVersion})
+				}
+				return entry.Value == newVersion
+			}
+		}
+		if p.Model.Parent == nil {
+			return false
+		}
+		groupID, artifactID, _ := parentCoordinates(*p.Model.Parent)
+		p = r.Project(groupID + ":" + artifactID)
+	}
+	return false
+}
+
+// Files returns the new contents of every file that changed, by path
+func (u *VersionUpdate) Files() (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	for _, change := range u.Changes {
+		if _, ok := files[change.Path]; ok {
+			continue
+		}
+		data, err := MarshalDocument(u.documents[change.Path])
+		if err != nil {
+			return nil, fmt.Errorf("pom: %s: %v", change.Path, err)
+		}
+		files[change.Path] = data
+	}
+	return files, nil
+}
+
+// Write writes every file that changed in place
+func (u *VersionUpdate) Write() error {
+	files, err := u.Files()
+	if err != nil {
+		return err
+	}
+	for path, data := range files {
+		mode := os.FileMode(0644)
+		if info, err := os.Stat(path); err == nil {
+			mode = info.Mode()
+		}
+		if err := ioutil.WriteFile(path, data, mode); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Report lists the changes, grouped by file:
+//   app/pom.xml
+//       project/parent/version: 1.0-SNAPSHOT -> 1.0
+func (u *VersionUpdate) Report() string {
+	paths := make([]string, 0)
+	byPath := make(map[string][]VersionChange)
+	for _, change := range u.Changes {
+		if _, ok := byPath[change.Path]; !ok {
+			paths = append(paths, change.Path)
+		}
+		byPath[change.Path] = append(byPath[change.Path], change)
+	}
+	sort.Strings(paths)
+	b := &strings.Builder{}
+	for _, path := range paths {
+		b.WriteString(path + "\n")
+		for _, change := range byPath[path] {
+			fmt.Fprintf(b, "    %s: %s -> %s\n", change.Location, change.OldValue, change.NewValue)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,265 @@
+package pom
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var setVersionRootPom = `<?xml version="1.0" encoding="UTF-8"?>
+<project>
+  <groupId>com.example</groupId>
+  <artifactId>root</artifactId>
+  <version>1.0-SNAPSHOT</version>
+  <packaging>pom</packaging>
+  <modules>
+    <module>core</module>
+    <module>app</module>
+    <module>tool</module>
+  </modules>
+  <properties>
+    <!-- keep in step with the project -->
+    <core.version>1.0-SNAPSHOT</core.version>
+  </properties>
+  <dependencyManagement>
+    <dependencies>
+      <dependency>
+        <groupId>com.example</groupId>
+        <artifactId>core</artifactId>
+        <version>${core.version}</version>
+      </dependency>
+    </dependencies>
+  </dependencyManagement>
+</project>
+`
+
+var setVersionCorePom = `<project>
+  <parent>
+    <groupId>com.example</groupId>
+    <artifactId>root</artifactId>
+    <version>1.0-SNAPSHOT</version>
+  </parent>
+  <artifactId>core</artifactId>
+</project>
+`
+
+var setVersionAppPom = `<project>
+  <parent>
+    <groupId>com.example</groupId>
+    <artifactId>root</artifactId>
+    <version>1.0-SNAPSHOT</version>
+  </parent>
+  <artifactId>app</artifactId>
+  <dependencies>
+    <dependency>
+      <groupId>${project.groupId}</groupId>
+      <artifactId>core</artifactId>
+    </dependency>
+    <dependency>
+      <groupId>com.example</groupId>
+      <artifactId>tool</artifactId>
+      <version>2.0</version>
+    </dependency>
+  </dependencies>
+</project>
+`
+
+// setVersionToolPom has a version of its own, so only its parent changes
+var setVersionToolPom = `<project>
+  <parent>
+    <groupId>com.example</groupId>
+    <artifactId>root</artifactId>
+    <version>1.0-SNAPSHOT</version>
+  </parent>
+  <artifactId>tool</artifactId>
+  <version>2.0</version>
+  <build>
+    <plugins>
+      <plugin>
+        <groupId>com.example</groupId>
+        <artifactId>core</artifactId>
+        <version>1.0-SNAPSHOT</version>
+      </plugin>
+    </plugins>
+  </build>
+</project>
+`
+
+func TestSetVersion(t *testing.T) {
+	a := assert.New(t)
+	dir, err := ioutil.TempDir("", "pom")
+	a.NoError(err)
+	defer os.RemoveAll(dir)
+	a.NoError(writeReactor(dir, map[string]string{
+		"pom.xml":      setVersionRootPom,
+		"core/pom.xml": setVersionCorePom,
+		"app/pom.xml":  setVersionAppPom,
+		"tool/pom.xml": setVersionToolPom,
+	}))
+
+	r, err := LoadReactor(dir, nil)
+	a.NoError(err)
+	// app inherits its version, so the root changes, and everything with it
+	update, err := r.SetVersion("com.example", "app", "1.0")
+	a.NoError(err)
+
+	rel := func(path string) string {
+		return filepath.Join(dir, filepath.FromSlash(path))
+	}
+	a.Equal([]VersionChange{
+		{Path: rel("pom.xml"), Location: "project/version", OldValue: "1.0-SNAPSHOT", NewValue: "1.0"},
+		{Path: rel("core/pom.xml"), Location: "project/parent/version", OldValue: "1.0-SNAPSHOT", NewValue: "1.0"},
+		{Path: rel("app/pom.xml"), Location: "project/parent/version", OldValue: "1.0-SNAPSHOT", NewValue: "1.0"},
+		{Path: rel("tool/pom.xml"), Location: "project/parent/version", OldValue: "1.0-SNAPSHOT", NewValue: "1.0"},
+		{Path: rel("pom.xml"), Location: "project/properties/core.version", OldValue: "1.0-SNAPSHOT", NewValue: "1.0"},
+		{Path: rel("tool/pom.xml"), Location: "project/build/plugins/plugin[com.example:core]/version", OldValue: "1.0-SNAPSHOT", NewValue: "1.0"},
+	}, update.Changes)
+
+	// Every change can be found in the POM it was made in
+	for _, change := range update.Changes {
+		_, ok := update.documents[change.Path].Positions().Lookup(change.Location)
+		a.True(ok, change.Location)
+	}
+
+	report := update.Report()
+	a.True(strings.HasPrefix(report, rel("app/pom.xml")+"\n    project/parent/version: 1.0-SNAPSHOT -> 1.0\n"), report)
+
+	// Nothing is written until asked
+	data, err := ioutil.ReadFile(rel("pom.xml"))
+	a.NoError(err)
+	a.Equal(setVersionRootPom, string(data))
+
+	a.NoError(update.Write())
+	data, err = ioutil.ReadFile(rel("pom.xml"))
+	a.NoError(err)
+	a.Equal(strings.Replace(setVersionRootPom, "1.0-SNAPSHOT", "1.0", -1), string(data))
+	data, err = ioutil.ReadFile(rel("tool/pom.xml"))
+	a.NoError(err)
+	a.Equal(strings.Replace(setVersionToolPom, "1.0-SNAPSHOT", "1.0", -1), string(data))
+
+	// tool has its own version, which only it and app's dependency on it use
+	r, err = LoadReactor(dir, nil)
+	a.NoError(err)
+	update, err = r.SetVersion("com.example", "tool", "2.1")
+	a.NoError(err)
+	a.Equal([]VersionChange{
+		{Path: rel("tool/pom.xml"), Location: "project/version", OldValue: "2.0", NewValue: "2.1"},
+		{Path: rel("app/pom.xml"), Location: "project/dependencies/dependency[com.example:tool:jar]/version", OldValue: "2.0", NewValue: "2.1"},
+	}, update.Changes)
+
+	_, err = r.SetVersion("com.example", "missing", "1")
+	a.Error(err)
+}
+
+func TestSetVersionRepeatedChildVersion(t *testing.T) {
+	a := assert.New(t)
+	dir, err := ioutil.TempDir("", "pom")
+	a.NoError(err)
+	defer os.RemoveAll(dir)
+	a.NoError(writeReactor(dir, map[string]string{
+		"pom.xml": `<project>
+  <groupId>com.example</groupId>
+  <artifactId>root</artifactId>
+  <version>1.0-SNAPSHOT</version>
+  <packaging>pom</packaging>
+  <modules>
+    <module>api</module>
+  </modules>
+</project>
+`,
+		"api/pom.xml": `<project>
+  <parent>
+    <groupId>com.example</groupId>
+    <artifactId>root</artifactId>
+    <version>1.0-SNAPSHOT</version>
+  </parent>
+  <artifactId>api</artifactId>
+  <version>1.0-SNAPSHOT</version>
+  <packaging>pom</packaging>
+  <modules>
+    <module>client</module>
+  </modules>
+</project>
+`,
+		"api/client/pom.xml": `<project>
+  <parent>
+    <groupId>com.example</groupId>
+    <artifactId>api</artifactId>
+    <version>1.0-SNAPSHOT</version>
+  </parent>
+  <artifactId>client</artifactId>
+</project>
+`,
+	}))
+
+	r, err := LoadReactor(dir, nil)
+	a.NoError(err)
+	update, err := r.SetVersion("com.example", "root", "1.0")
+	a.NoError(err)
+
+	rel := func(path string) string {
+		return filepath.Join(dir, filepath.FromSlash(path))
+	}
+	a.Equal([]VersionChange{
+		{Path: rel("pom.xml"), Location: "project/version", OldValue: "1.0-SNAPSHOT", NewValue: "1.0"},
+		{Path: rel("api/pom.xml"), Location: "project/parent/version", OldValue: "1.0-SNAPSHOT", NewValue: "1.0"},
+		{Path: rel("api/pom.xml"), Location: "project/version", OldValue: "1.0-SNAPSHOT", NewValue: "1.0"},
+		{Path: rel("api/client/pom.xml"), Location: "project/parent/version", OldValue: "1.0-SNAPSHOT", NewValue: "1.0"},
+	}, update.Changes)
+}
+
+func TestSetVersionProperty(t *testing.T) {
+	a := assert.New(t)
+	dir, err := ioutil.TempDir("", "pom")
+	a.NoError(err)
+	defer os.RemoveAll(dir)
+	rootPom := `<project>
+  <groupId>com.example</groupId>
+  <artifactId>root</artifactId>
+  <version>${revision}</version>
+  <packaging>pom</packaging>
+  <modules>
+    <module>core</module>
+  </modules>
+  <properties>
+    <revision>1.0-SNAPSHOT</revision>
+  </properties>
+</project>
+`
+	corePom := `<project>
+  <parent>
+    <groupId>com.example</groupId>
+    <artifactId>root</artifactId>
+    <version>${revision}</version>
+  </parent>
+  <artifactId>core</artifactId>
+</project>
+`
+	a.NoError(writeReactor(dir, map[string]string{"pom.xml": rootPom, "core/pom.xml": corePom}))
+
+	r, err := LoadReactor(dir, nil)
+	a.NoError(err)
+	update, err := r.SetVersion("com.example", "core", "1.0")
+	a.NoError(err)
+	// The expressions are kept, and only the property they use changes
+	a.Equal([]VersionChange{
+		{Path: filepath.Join(dir, "pom.xml"), Location: "project/properties/revision", OldValue: "1.0-SNAPSHOT", NewValue: "1.0"},
+	}, update.Changes)
+	files, err := update.Files()
+	a.NoError(err)
+	a.Equal(strings.Replace(rootPom, "<revision>1.0-SNAPSHOT</revision>", "<revision>1.0</revision>", 1), string(files[filepath.Join(dir, "pom.xml")]))
+
+	// Without the property in the reactor, there is nothing that can be changed
+	a.NoError(writeReactor(dir, map[string]string{
+		"pom.xml":      strings.Replace(rootPom, "${revision}", "${project.major}-SNAPSHOT", 1),
+		"core/pom.xml": strings.Replace(corePom, "${revision}", "${project.major}-SNAPSHOT", 1),
+	}))
+	r, err = LoadReactor(dir, nil)
+	a.NoError(err)
+	_, err = r.SetVersion("com.example", "root", "2.0")
+	a.EqualError(err, "pom: "+filepath.Join(dir, "pom.xml")+": cannot change project/version from ${project.major}-SNAPSHOT, as it comes from ${project.major}-SNAPSHOT")
+}
@@ -0,0 +1,141 @@
+package pom
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Reasons a node can be omitted from a dependency graph
+const (
+	OmittedForDuplicate = "duplicate"
+	OmittedForConflict  = "conflict"
+	OmittedForCycle     = "cycle"
+)
+
+// OmittedReason says why the node lost mediation, or is empty if it did not
+func (n *DependencyNode) OmittedReason() string {
+	if n.OmittedFor == nil {
+		return ""
+	}
+	for ancestor := n.parent; ancestor != nil; ancestor = ancestor.parent {
+		if ancestor == n.OmittedFor {
+			return OmittedForCycle
+		}
+	}
+	if n.OmittedFor.Version == n.Version {
+		return OmittedForDuplicate
+	}
+	return OmittedForConflict
+}
+
+// Tree renders the graph below n the way mvn dependency:tree does.
+// With verbose, nodes that lost mediation are included too, like -Dverbose.
+//   com.example:app:jar:1
+//   +- com.example:a:jar:1:compile
+//   |  \- com.example:c:jar:2:compile (version managed from 1)
+//   \- com.example:b:jar:1:compile
+//      \- (com.example:c:jar:3:compile - omitted for conflict with 2)
+func (n *DependencyNode) Tree(verbose bool) string {
+	b := &strings.Builder{}
+	b.WriteString(n.treeString())
+	b.WriteString("\n")
+	n.writeChildren(b, "", verbose)
+	return b.String()
+}
+
+func (n *DependencyNode) writeChildren(b *strings.Builder, indent string, verbose bool) {
+	children := make([]*DependencyNode, 0, len(n.Children))
+	for _, child := range n.Children {
+		if verbose || child.OmittedFor == nil {
+			children = append(children, child)
+		}
+	}
+	for i, child := range children {
+		last := i == len(children)-1
+		b.WriteString(indent)
+		if last {
+			b.WriteString(`\- `)
+		} else {
+			b.WriteString("+- ")
+		}
+		b.WriteString(child.treeString())
+		b.WriteString("\n")
+		if last {
+			child.writeChildren(b, indent+"   ", verbose)
+		} else {
+			child.writeChildren(b, indent+"|  ", verbose)
+		}
+	}
+}
+
+// treeString is the line for a single node, with its annotations
+func (n *DependencyNode) treeString() string {
+	included := n.OmittedFor == nil
+	notes := make([]string, 0)
+	if n.PremanagedVersion != "" {
+		notes = append(notes, "version managed from "+n.PremanagedVersion)
+	}
+	if n.PremanagedScope != "" {
+		notes = append(notes, "scope managed from "+n.PremanagedScope)
+	}
+	if n.ScopeUpdatedFrom != "" {
+		notes = append(notes, "scope updated from "+n.ScopeUpdatedFrom)
+	}
+	switch n.OmittedReason() {
+	case OmittedForDuplicate:
+		notes = append(notes, "omitted for duplicate")
+	case OmittedForConflict:
+		notes = append(notes, "omitted for conflict with "+n.OmittedFor.Version)
+	case OmittedForCycle:
+		notes = append(notes, "omitted for cycle")
+	}
+
+	if included {
+		if len(notes) == 0 {
+			return n.String()
+		}
+		return n.String() + " (" + strings.Join(notes, "; ") + ")"
+	}
+	return "(" + n.String() + " - " + strings.Join(notes, "; ") + ")"
+}
+
+// dependencyNodeJSON is how a DependencyNode is written as JSON.
+// The fields are always in this order, so the output can be diffed.
+type dependencyNodeJSON struct {
+	GroupID           string            `json:"groupId"`
+	ArtifactID        string            `json:"artifactId"`
+	Version           string            `json:"version"`
+	Type              string            `json:"type"`
+	Classifier        string            `json:"classifier,omitempty"`
+	Scope             string            `json:"scope,omitempty"`
+	Optional          bool              `json:"optional,omitempty"`
+	PremanagedVersion string            `json:"premanagedVersion,omitempty"`
+	PremanagedScope   string            `json:"premanagedScope,omitempty"`
+	ScopeUpdatedFrom  string            `json:"scopeUpdatedFrom,omitempty"`
+	Omitted           string            `json:"omitted,omitempty"`
+	OmittedFor        string            `json:"omittedFor,omitempty"`
+	Children          []*DependencyNode `json:"children,omitempty"`
+}
+
+// MarshalJSON writes the node and everything below it, including the nodes that lost mediation.
+// Those name the node they lost to in omittedFor.
+func (n *DependencyNode) MarshalJSON() ([]byte, error) {
+	node := dependencyNodeJSON{
+		GroupID:           n.GroupID,
+		ArtifactID:        n.ArtifactID,
+		Version:           n.Version,
+		Type:              n.Type,
+		Classifier:        n.Classifier,
+		Scope:             n.Scope,
+		Optional:          n.Optional,
+		PremanagedVersion: n.PremanagedVersion,
+		PremanagedScope:   n.PremanagedScope,
+		ScopeUpdatedFrom:  n.ScopeUpdatedFrom,
+		Omitted:           n.OmittedReason(),
+		Children:          n.Children,
+	}
+	if n.OmittedFor != nil {
+		node.OmittedFor = n.OmittedFor.String()
+	}
+	return json.Marshal(node)
+}
@@ -0,0 +1,98 @@
+package pom
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var conflictRepository = map[string]string{
+	"com.example:x:1": `<project><groupId>com.example</groupId><artifactId>x</artifactId><version>1</version>
+    <dependencies><dependency><groupId>com.example</groupId><artifactId>y</artifactId><version>1</version></dependency></dependencies>
+</project>`,
+	"com.example:y:1": `<project><groupId>com.example</groupId><artifactId>y</artifactId><version>1</version>
+    <dependencies><dependency><groupId>com.example</groupId><artifactId>x</artifactId><version>1</version></dependency></dependencies>
+</project>`,
+	"com.example:y:2": leafPom("y", "2"),
+	"com.example:z:1": `<project><groupId>com.example</groupId><artifactId>z</artifactId><version>1</version>
+    <dependencies><dependency><groupId>com.example</groupId><artifactId>y</artifactId><version>2</version><classifier>tests</classifier></dependency>
+    <dependency><groupId>com.example</groupId><artifactId>y</artifactId><version>2</version></dependency></dependencies>
+</project>`,
+}
+
+var conflictProjectPom = `<project>
+    <groupId>com.example</groupId>
+    <artifactId>app</artifactId>
+    <version>1</version>
+    <packaging>war</packaging>
+    <dependencies>
+        <dependency><groupId>com.example</groupId><artifactId>x</artifactId><version>1</version></dependency>
+        <dependency><groupId>com.example</groupId><artifactId>z</artifactId><version>1</version><scope>runtime</scope></dependency>
+    </dependencies>
+</project>`
+
+func resolveFixture(t *testing.T, repository map[string]string, project string) *DependencyNode {
+	a := assert.New(t)
+	dir, err := ioutil.TempDir("", "pom")
+	a.NoError(err)
+	defer os.RemoveAll(dir)
+	a.NoError(writeRepository(dir, repository))
+	model, err := Unmarshal([]byte(project))
+	a.NoError(err)
+	root, err := NewDependencyResolver(dir).Resolve(model)
+	a.NoError(err)
+	return root
+}
+
+func TestDependencyTree(t *testing.T) {
+	a := assert.New(t)
+	root := resolveFixture(t, conflictRepository, conflictProjectPom)
+
+	a.Equal(`com.example:app:war:1
++- com.example:x:jar:1:compile
+|  \- com.example:y:jar:1:compile
+\- com.example:z:jar:1:runtime
+   \- com.example:y:jar:tests:2:runtime
+`, root.Tree(false))
+
+	a.Equal(`com.example:app:war:1
++- com.example:x:jar:1:compile
+|  \- com.example:y:jar:1:compile
+|     \- (com.example:x:jar:1:compile - omitted for cycle)
+\- com.example:z:jar:1:runtime
+   +- com.example:y:jar:tests:2:runtime
+   \- (com.example:y:jar:2:runtime - omitted for conflict with 1)
+`, root.Tree(true))
+
+	root = resolveFixture(t, dependencyRepository, dependencyProjectPom)
+	a.Equal(`com.example:app:jar:1
++- com.example:a:jar:1:compile
+|  +- com.example:c:jar:2:compile (version managed from 1)
+|  \- com.example:r:jar:1:runtime
++- com.example:t:jar:1:test
+|  \- com.example:h:jar:1:compile (scope updated from test)
+\- com.example:b:jar:1:compile
+   +- (com.example:c:jar:2:compile - version managed from 3; omitted for duplicate)
+   +- com.example:f:jar:1.5:compile
+   \- (com.example:h:jar:1:compile - omitted for duplicate)
+`, root.Tree(true))
+}
+
+func TestDependencyTreeJSON(t *testing.T) {
+	a := assert.New(t)
+	root := resolveFixture(t, conflictRepository, conflictProjectPom)
+
+	data, err := json.Marshal(root.Children[1])
+	a.NoError(err)
+	a.Equal(`{"groupId":"com.example","artifactId":"z","version":"1","type":"jar","scope":"runtime","children":[`+
+		`{"groupId":"com.example","artifactId":"y","version":"2","type":"jar","classifier":"tests","scope":"runtime"},`+
+		`{"groupId":"com.example","artifactId":"y","version":"2","type":"jar","scope":"runtime","omitted":"conflict","omittedFor":"com.example:y:jar:1:compile"}]}`, string(data))
+
+	// The same graph always comes out the same
+	again, err := json.Marshal(resolveFixture(t, conflictRepository, conflictProjectPom).Children[1])
+	a.NoError(err)
+	a.Equal(string(data), string(again))
+}
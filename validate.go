@@ -0,0 +1,337 @@
+package pom
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ValidationLevel is how strictly a model is validated, the same as Maven's
+// ModelBuildingRequest validation levels
+type ValidationLevel int
+
+const (
+	// ValidationLevelMinimal only checks what is needed to identify the project
+	ValidationLevelMinimal ValidationLevel = 0
+	// ValidationLevelMaven20 checks everything Maven 2.0 did
+	ValidationLevelMaven20 ValidationLevel = 20
+	// ValidationLevelMaven30 turns some of the Maven 2.0 warnings into errors
+	ValidationLevelMaven30 ValidationLevel = 30
+	// ValidationLevelMaven31 turns duplicate declarations into errors
+	ValidationLevelMaven31 ValidationLevel = 31
+	// ValidationLevelStrict is what Maven uses for the projects it builds
+	ValidationLevelStrict = ValidationLevelMaven30
+)
+
+// Severity is how bad a Problem is
+type Severity int
+
+// Severities of problems, worst first
+const (
+	SeverityFatal Severity = iota
+	SeverityError
+	SeverityWarning
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityFatal:
+		return "FATAL"
+	case SeverityError:
+		return "ERROR"
+	}
+	return "WARNING"
+}
+
+// Problem is something wrong with a model
+type Problem struct {
+	Severity Severity
+	// Path is where the problem is, like project/dependencies/dependency[junit:junit:jar]/version
+	Path string
+	// Message is the same as Maven's, like 'dependencies.dependency.version' for junit:junit:jar is missing.
+	Message string
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("[%s] %s", p.Severity, p.Message)
+}
+
+// validID is what groupIds, artifactIds and packaging have to look like
+var validID = regexp.MustCompile(`^[A-Za-z0-9_\-.]+$`)
+
+var (
+	dependencyScopes = []string{"provided", "compile", "runtime", "test", "system"}
+	managedScopes    = []string{"provided", "compile", "runtime", "test", "system", "import"}
+)
+
+type validator struct {
+	level    ValidationLevel
+	problems []Problem
+}
+
+func (v *validator) add(severity Severity, path, message string, args ...interface{}) {
+	v.problems = append(v.problems, Problem{Severity: severity, Path: path, Message: fmt.Sprintf(message, args...)})
+}
+
+// errorFrom is an error from level on, and a warning before that
+func (v *validator) errorFrom(level ValidationLevel) Severity {
+	if v.level >= level {
+		return SeverityError
+	}
+	return SeverityWarning
+}
+
+// Validate checks the model the way Maven's ModelValidator does, and returns
+// everything that is wrong with it, in the order the checks were made.
+// Nothing is inherited into the model, so validate the result of Effective to
+// take parents, and versions managed by them, into account.
+// Usage:
+//   for _, problem := range model.Validate(pom.ValidationLevelStrict) {
+//       fmt.Println(problem.Path, problem)
+//   }
+func (a *Model) Validate(level ValidationLevel) []Problem {
+	v := &validator{level: level}
+	v.validateParent(a)
+	v.validateProject(a)
+	if level < ValidationLevelMaven20 {
+		return v.problems
+	}
+
+	managed := make(map[string]bool)
+	if a.DependencyManagement != nil && a.DependencyManagement.Dependencies != nil {
+		for _, d := range a.DependencyManagement.Dependencies.Dependency {
+			managed[dependencyKey(d)] = true
+		}
+	}
+	v.validateModules("project/", a.Modules)
+	v.validateDependencies("project/", a.Dependencies, false, managed)
+	if a.DependencyManagement != nil {
+		v.validateDependencies("project/", a.DependencyManagement.Dependencies, true, nil)
+	}
+	if a.Build != nil {
+		managedPlugins := make(map[string]bool)
+		if a.Build.PluginManagement != nil {
+			if a.Build.PluginManagement.Plugins != nil {
+				for _, plugin := range a.Build.PluginManagement.Plugins.Plugin {
+					if plugin.Version != nil {
+						managedPlugins[pluginKey(plugin.GroupID, plugin.ArtifactID)] = true
+					}
+				}
+			}
+			v.validatePlugins("project/build/pluginManagement/", "build.pluginManagement.plugins.plugin", a.Build.PluginManagement.Plugins, nil)
+		}
+		v.validatePlugins("project/build/", "build.plugins.plugin", a.Build.Plugins, managedPlugins)
+	}
+	v.validateProfiles(a.Profiles, managed)
+	return v.problems
+}
+
+func (v *validator) validateParent(m *Model) {
+	if m.Parent == nil {
+		return
+	}
+	groupID, artifactID, version := parentCoordinates(*m.Parent)
+	for _, field := range []struct{ name, value string }{{"groupId", groupID}, {"artifactId", artifactID}, {"version", version}} {
+		if strings.TrimSpace(field.value) == "" {
+			v.add(SeverityFatal, "project/parent/"+field.name, "'parent.%s' is missing.", field.name)
+		}
+	}
+	ownGroupID, _ := m.GetGroupID()
+	ownArtifactID, _ := m.GetArtifactID()
+	if artifactID != "" && artifactID == ownArtifactID && (ownGroupID == "" || ownGroupID == groupID) {
+		v.add(SeverityFatal, "project/parent/artifactId", "'parent.artifactId' must be changed, the parent element cannot have the same groupId:artifactId as the project.")
+	}
+}
+
+func (v *validator) validateProject(m *Model) {
+	if v.level >= ValidationLevelMaven20 {
+		modelVersion, _ := m.GetModelVersion()
+		switch strings.TrimSpace(modelVersion) {
+		case "":
+			v.add(SeverityError, "project/modelVersion", "'modelVersion' is missing.")
+		case "4.0.0":
+		default:
+			v.add(SeverityFatal, "project/modelVersion", "'modelVersion' must be one of [4.0.0] but is '%s'.", modelVersion)
+		}
+	}
+
+	groupID, _ := m.GetGroupID()
+	artifactID, _ := m.GetArtifactID()
+	version, _ := m.GetVersion()
+	if m.Parent != nil {
+		// These are inherited when they are left out
+		parentGroupID, _, parentVersion := parentCoordinates(*m.Parent)
+		if groupID == "" {
+			groupID = parentGroupID
+		}
+		if version == "" {
+			version = parentVersion
+		}
+	}
+	v.validateID("project/groupId", "groupId", groupID)
+	v.validateID("project/artifactId", "artifactId", artifactID)
+	if strings.TrimSpace(version) == "" {
+		v.add(SeverityError, "project/version", "'version' is missing.")
+	}
+
+	packaging, ok := m.GetPackaging()
+	if !ok || strings.TrimSpace(packaging) == "" {
+		packaging = "jar"
+	}
+	if !strings.Contains(packaging, "${") && !validID.MatchString(strings.TrimSpace(packaging)) {
+		v.add(SeverityError, "project/packaging", "'packaging' with value '%s' does not match a valid id pattern.", packaging)
+	} else if m.Modules != nil && len(m.Modules.Module) > 0 && strings.TrimSpace(packaging) != "pom" {
+		v.add(SeverityError, "project/packaging", "'packaging' with value '%s' is invalid. Aggregator projects require 'pom' as packaging.", packaging)
+	}
+}
+
+// validateID checks that an id is there, and has nothing in it Maven does not allow
+func (v *validator) validateID(path, field, value string) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		v.add(SeverityError, path, "'%s' is missing.", field)
+	} else if !strings.Contains(value, "${") && !validID.MatchString(value) {
+		// Expressions are only checked once they are interpolated
+		v.add(SeverityError, path, "'%s' with value '%s' does not match a valid id pattern.", field, value)
+	}
+}
+
+func (v *validator) validateModules(base string, modules *SequenceModule) {
+	if modules == nil {
+		return
+	}
+	seen := make(map[string]bool)
+	for i, module := range modules.Module {
+		if module == nil {
+			continue
+		}
+		name := strings.TrimSpace(*module)
+		if seen[name] {
+			v.add(SeverityError, fmt.Sprintf("%smodules/module[%d]", base, i), "'modules.module[%d]' specifies duplicate child module %s", i, name)
+		}
+		seen[name] = true
+	}
+}
+
+// validateDependencies checks a list of dependencies.  managed holds the keys
+// of the dependencies whose versions come from dependencyManagement.
+func (v *validator) validateDependencies(base string, dependencies *SequenceDependency, management bool, managed map[string]bool) {
+	if dependencies == nil {
+		return
+	}
+	field, path := "dependencies.dependency", base+"dependencies/dependency"
+	scopes := dependencyScopes
+	if management {
+		field, path = "dependencyManagement.dependencies.dependency", base+"dependencyManagement/dependencies/dependency"
+		scopes = managedScopes
+	}
+
+	seen := make(map[string]bool)
+	for _, d := range dependencies.Dependency {
+		key := strings.TrimSuffix(dependencyKey(d), ":")
+		at := path + "[" + key + "]"
+		version, hasVersion := d.GetVersion()
+		version = strings.TrimSpace(version)
+		if seen[key] {
+			if !hasVersion {
+				version = "(?)"
+			}
+			v.add(v.errorFrom(ValidationLevelMaven31), at, "'%s.(groupId:artifactId:type:classifier)' must be unique: %s -> duplicate declaration of version %s", field, key, version)
+		}
+		seen[key] = true
+
+		groupID, _ := d.GetGroupID()
+		artifactID, _ := d.GetArtifactID()
+		if strings.TrimSpace(groupID) == "" {
+			v.add(SeverityError, at+"/groupId", "'%s.groupId' for %s is missing.", field, key)
+		} else if !strings.Contains(groupID, "${") && !validID.MatchString(strings.TrimSpace(groupID)) {
+			v.add(SeverityError, at+"/groupId", "'%s.groupId' for %s with value '%s' does not match a valid id pattern.", field, key, groupID)
+		}
+		if strings.TrimSpace(artifactID) == "" {
+			v.add(SeverityError, at+"/artifactId", "'%s.artifactId' for %s is missing.", field, key)
+		} else if !strings.Contains(artifactID, "${") && !validID.MatchString(strings.TrimSpace(artifactID)) {
+			v.add(SeverityError, at+"/artifactId", "'%s.artifactId' for %s with value '%s' does not match a valid id pattern.", field, key, artifactID)
+		}
+
+		scope, _ := d.GetScope()
+		scope = strings.TrimSpace(scope)
+		// dependencyManagement may leave the version to whoever uses it, to only manage a scope or exclusions
+		if version == "" && !management && !managed[dependencyKey(d)] {
+			v.add(SeverityError, at+"/version", "'%s.version' for %s is missing.", field, key)
+		} else if version == "RELEASE" || version == "LATEST" {
+			v.add(SeverityWarning, at+"/version", "'%s.version' for %s is either LATEST or RELEASE (both of them are being deprecated)", field, key)
+		}
+		if scope != "" && !strings.Contains(scope, "${") && !containsString(scopes, scope) {
+			v.add(SeverityWarning, at+"/scope", "'%s.scope' for %s must be one of [%s] but is '%s'.", field, key, strings.Join(scopes, ", "), scope)
+		}
+		if management && scope == "import" {
+			if dependencyType, _ := d.GetType(); strings.TrimSpace(dependencyType) != "pom" {
+				v.add(SeverityError, at+"/type", "'%s.type' for %s must be 'pom' to import the managed dependencies.", field, key)
+			}
+		}
+
+		systemPath, hasSystemPath := d.GetSystemPath()
+		if scope == "system" && strings.TrimSpace(systemPath) == "" {
+			v.add(SeverityError, at+"/systemPath", "'%s.systemPath' for %s is missing.", field, key)
+		} else if hasSystemPath && scope != "system" && !strings.Contains(scope, "${") && !management {
+			v.add(SeverityError, at+"/systemPath", "'%s.systemPath' for %s must be omitted. This field may only be specified for a dependency with system scope.", field, key)
+		}
+	}
+}
+
+// validatePlugins checks a list of plugins.  managed holds the keys of the plugins whose versions come from pluginManagement.
+func (v *validator) validatePlugins(base, field string, plugins *SequencePlugin, managed map[string]bool) {
+	if plugins == nil {
+		return
+	}
+	seen := make(map[string]bool)
+	for _, plugin := range plugins.Plugin {
+		key := pluginKey(plugin.GroupID, plugin.ArtifactID)
+		at := base + "plugins/plugin[" + key + "]"
+		if seen[key] {
+			v.add(v.errorFrom(ValidationLevelMaven31), at, "'%s.(groupId:artifactId)' must be unique but found duplicate declaration of plugin %s", field, key)
+		}
+		seen[key] = true
+
+		if artifactID, _ := plugin.GetArtifactID(); strings.TrimSpace(artifactID) == "" {
+			v.add(SeverityError, at+"/artifactId", "'%s.artifactId' is missing.", field)
+		}
+		if version, _ := plugin.GetVersion(); strings.TrimSpace(version) == "" && managed != nil && !managed[key] {
+			v.add(SeverityWarning, at+"/version", "'%s.version' for %s is missing.", field, key)
+		}
+	}
+}
+
+func (v *validator) validateProfiles(profiles *SequenceProfile, managed map[string]bool) {
+	if profiles == nil {
+		return
+	}
+	seen := make(map[string]bool)
+	for i, profile := range profiles.Profile {
+		id, _ := profile.GetID()
+		id = strings.TrimSpace(id)
+		at := fmt.Sprintf("project/profiles/profile[%s]", id)
+		if id == "" {
+			at = fmt.Sprintf("project/profiles/profile[%d]", i)
+			v.add(SeverityError, at+"/id", "'profiles.profile[%d].id' is missing.", i)
+		} else if seen[id] {
+			v.add(SeverityError, at+"/id", "'profiles.profile.id' must be unique but found duplicate profile with id %s", id)
+		}
+		seen[id] = true
+
+		profileManaged := make(map[string]bool)
+		for key := range managed {
+			profileManaged[key] = true
+		}
+		if profile.DependencyManagement != nil {
+			if profile.DependencyManagement.Dependencies != nil {
+				for _, d := range profile.DependencyManagement.Dependencies.Dependency {
+					profileManaged[dependencyKey(d)] = true
+				}
+			}
+			v.validateDependencies(at+"/", profile.DependencyManagement.Dependencies, true, nil)
+		}
+		v.validateDependencies(at+"/", profile.Dependencies, false, profileManaged)
+		v.validateModules(at+"/", profile.Modules)
+	}
+}
@@ -0,0 +1,136 @@
+package pom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var brokenPom = `<project>
+    <modelVersion>4.1.0</modelVersion>
+    <groupId>com.example</groupId>
+    <artifactId>broken app</artifactId>
+    <version>1</version>
+    <modules>
+        <module>core</module>
+        <module>core</module>
+    </modules>
+    <dependencyManagement>
+        <dependencies>
+            <dependency>
+                <groupId>com.example</groupId>
+                <artifactId>bom</artifactId>
+                <version>1</version>
+                <scope>import</scope>
+            </dependency>
+            <dependency>
+                <groupId>io.netty</groupId>
+                <artifactId>netty-all</artifactId>
+                <version>4.1.42.Final</version>
+            </dependency>
+            <dependency>
+                <!-- only manages exclusions, so it has no version -->
+                <groupId>commons-logging</groupId>
+                <artifactId>commons-logging</artifactId>
+                <exclusions>
+                    <exclusion><groupId>*</groupId><artifactId>*</artifactId></exclusion>
+                </exclusions>
+            </dependency>
+        </dependencies>
+    </dependencyManagement>
+    <dependencies>
+        <dependency>
+            <groupId>junit</groupId>
+            <artifactId>junit</artifactId>
+            <version>4.12</version>
+            <scope>testing</scope>
+        </dependency>
+        <dependency>
+            <groupId>junit</groupId>
+            <artifactId>junit</artifactId>
+            <version>4.13</version>
+        </dependency>
+        <dependency>
+            <groupId>io.netty</groupId>
+            <artifactId>netty-all</artifactId>
+        </dependency>
+        <dependency>
+            <groupId>org.slf4j</groupId>
+            <artifactId>slf4j-api</artifactId>
+        </dependency>
+        <dependency>
+            <groupId>com.sun</groupId>
+            <artifactId>tools</artifactId>
+            <version>1.8</version>
+            <scope>system</scope>
+        </dependency>
+    </dependencies>
+    <build>
+        <plugins>
+            <plugin>
+                <artifactId>maven-compiler-plugin</artifactId>
+            </plugin>
+            <plugin>
+                <artifactId>maven-compiler-plugin</artifactId>
+                <version>3.8.1</version>
+            </plugin>
+        </plugins>
+    </build>
+    <profiles>
+        <profile><id>ci</id></profile>
+        <profile><id>ci</id></profile>
+    </profiles>
+</project>`
+
+func TestValidate(t *testing.T) {
+	a := assert.New(t)
+	model, err := Unmarshal([]byte(brokenPom))
+	a.NoError(err)
+
+	problems := model.Validate(ValidationLevelStrict)
+	messages := make([]string, 0, len(problems))
+	for _, problem := range problems {
+		messages = append(messages, problem.Path+" "+problem.String())
+	}
+	a.Equal([]string{
+		"project/modelVersion [FATAL] 'modelVersion' must be one of [4.0.0] but is '4.1.0'.",
+		"project/artifactId [ERROR] 'artifactId' with value 'broken app' does not match a valid id pattern.",
+		"project/packaging [ERROR] 'packaging' with value 'jar' is invalid. Aggregator projects require 'pom' as packaging.",
+		"project/modules/module[1] [ERROR] 'modules.module[1]' specifies duplicate child module core",
+		"project/dependencies/dependency[junit:junit:jar]/scope [WARNING] 'dependencies.dependency.scope' for junit:junit:jar must be one of [provided, compile, runtime, test, system] but is 'testing'.",
+		"project/dependencies/dependency[junit:junit:jar] [WARNING] 'dependencies.dependency.(groupId:artifactId:type:classifier)' must be unique: junit:junit:jar -> duplicate declaration of version 4.13",
+		"project/dependencies/dependency[org.slf4j:slf4j-api:jar]/version [ERROR] 'dependencies.dependency.version' for org.slf4j:slf4j-api:jar is missing.",
+		"project/dependencies/dependency[com.sun:tools:jar]/systemPath [ERROR] 'dependencies.dependency.systemPath' for com.sun:tools:jar is missing.",
+		"project/dependencyManagement/dependencies/dependency[com.example:bom:jar]/type [ERROR] 'dependencyManagement.dependencies.dependency.type' for com.example:bom:jar must be 'pom' to import the managed dependencies.",
+		"project/build/plugins/plugin[org.apache.maven.plugins:maven-compiler-plugin]/version [WARNING] 'build.plugins.plugin.version' for org.apache.maven.plugins:maven-compiler-plugin is missing.",
+		"project/build/plugins/plugin[org.apache.maven.plugins:maven-compiler-plugin] [WARNING] 'build.plugins.plugin.(groupId:artifactId)' must be unique but found duplicate declaration of plugin org.apache.maven.plugins:maven-compiler-plugin",
+		"project/profiles/profile[ci]/id [ERROR] 'profiles.profile.id' must be unique but found duplicate profile with id ci",
+	}, messages)
+
+	// Duplicates are errors from Maven 3.1 on
+	for _, problem := range model.Validate(ValidationLevelMaven31) {
+		if problem.Path == "project/dependencies/dependency[junit:junit:jar]" {
+			a.Equal(SeverityError, problem.Severity)
+		}
+	}
+
+	// The minimal level only checks the project's own coordinates
+	a.Len(model.Validate(ValidationLevelMinimal), 2)
+}
+
+func TestValidateInherited(t *testing.T) {
+	a := assert.New(t)
+	model, err := Unmarshal([]byte(`<project>
+    <modelVersion>4.0.0</modelVersion>
+    <parent><groupId>com.example</groupId><artifactId>parent</artifactId><version>1</version></parent>
+    <artifactId>child</artifactId>
+</project>`))
+	a.NoError(err)
+	a.Empty(model.Validate(ValidationLevelStrict), "groupId and version are inherited")
+
+	model.ArtifactID = optional("parent")
+	problems := model.Validate(ValidationLevelMinimal)
+	a.Len(problems, 1)
+	a.Equal(SeverityFatal, problems[0].Severity)
+	a.Equal("project/parent/artifactId", problems[0].Path)
+}
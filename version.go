@@ -0,0 +1,541 @@
+package pom
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Version is a Maven version, ordered exactly the way Maven's ComparableVersion orders them.
+// Versions are split into numbers and qualifiers, so 1.10 comes after 1.9 and
+// 1.0-alpha-1 < 1.0-beta-1 < 1.0-milestone-1 < 1.0-rc-1 < 1.0-SNAPSHOT < 1.0 < 1.0-sp-1.
+type Version struct {
+	raw   string
+	items listItem
+}
+
+// ParseVersion parses a version.  Any string is a valid Maven version.
+func ParseVersion(version string) Version {
+	return Version{raw: version, items: parseVersionItems(version)}
+}
+
+// String returns the version as it was written
+func (v Version) String() string {
+	return v.raw
+}
+
+// Canonical returns the normalized form Maven uses to compare versions, so
+// two versions are equal exactly when their canonical forms are
+func (v Version) Canonical() string {
+	return v.items.String()
+}
+
+// Compare returns -1 if v comes before other, 1 if it comes after, and 0 if they are the same version
+func (v Version) Compare(other Version) int {
+	return v.items.compare(other.items)
+}
+
+// versionItem is one piece of a parsed version
+type versionItem interface {
+	// compare compares the item to another, where nil is a missing item
+	compare(other versionItem) int
+	isNull() bool
+	String() string
+}
+
+// intItem is a number, kept as digits without leading zeros so it can be any size
+type intItem string
+
+func (i intItem) isNull() bool {
+	return i == "0"
+}
+
+func (i intItem) compare(other versionItem) int {
+	switch o := other.(type) {
+	case nil:
+		if i.isNull() {
+			return 0
+		}
+		return 1
+	case intItem:
+		if len(i) != len(o) {
+			if len(i) < len(o) {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(string(i), string(o))
+	case stringItem:
+		// 1.1 > 1-sp
+		return 1
+	default:
+		// 1.1 > 1-1
+		return 1
+	}
+}
+
+func (i intItem) String() string {
+	return string(i)
+}
+
+// qualifiers are the well known qualifiers, in order.  A release has the empty qualifier.
+var qualifiers = []string{"alpha", "beta", "milestone", "rc", "snapshot", "", "sp"}
+
+// qualifierAliases are other spellings of the well known qualifiers
+var qualifierAliases = map[string]string{
+	"ga":      "",
+	"final":   "",
+	"release": "",
+	"cr":      "rc",
+}
+
+// releaseQualifier is the comparable form of a release
+var releaseQualifier = comparableQualifier("")
+
+// stringItem is a qualifier, such as alpha or SNAPSHOT
+type stringItem string
+
+func newStringItem(value string, followedByDigit bool) stringItem {
+	if followedByDigit && len(value) == 1 {
+		// 1a1 is 1-alpha-1
+		switch value {
+		case "a":
+			value = "alpha"
+		case "b":
+			value = "beta"
+		case "m":
+			value = "milestone"
+		}
+	}
+	if alias, ok := qualifierAliases[value]; ok {
+		value = alias
+	}
+	return stringItem(value)
+}
+
+// comparableQualifier turns a qualifier into a string that sorts in the right order.
+// Well known qualifiers become their index, and anything else sorts after them, alphabetically.
+func comparableQualifier(qualifier string) string {
+	for i, known := range qualifiers {
+		if known == qualifier {
+			return fmt.Sprint(i)
+		}
+	}
+	return fmt.Sprintf("%d-%s", len(qualifiers), qualifier)
+}
+
+func (s stringItem) isNull() bool {
+	return comparableQualifier(string(s)) == releaseQualifier
+}
+
+func (s stringItem) compare(other versionItem) int {
+	switch o := other.(type) {
+	case nil:
+		// 1-rc < 1, 1-ga > 1
+		return strings.Compare(comparableQualifier(string(s)), releaseQualifier)
+	case stringItem:
+		return strings.Compare(comparableQualifier(string(s)), comparableQualifier(string(o)))
+	default:
+		// 1-sp < 1.1 and 1-sp < 1-1
+		return -1
+	}
+}
+
+func (s stringItem) String() string {
+	return string(s)
+}
+
+// listItem is a list of items.  Each - in a version starts a new sub-list.
+type listItem []versionItem
+
+func (l listItem) isNull() bool {
+	return len(l) == 0
+}
+
+func (l listItem) compare(other versionItem) int {
+	switch o := other.(type) {
+	case nil:
+		if len(l) == 0 {
+			// 1-0 = 1- (normalized) = 1
+			return 0
+		}
+		return l[0].compare(nil)
+	case intItem:
+		// 1-1 < 1.0.x
+		return -1
+	case stringItem:
+		// 1-1 > 1-sp
+		return 1
+	case listItem:
+		for i := 0; i < len(l) || i < len(o); i++ {
+			var left, right versionItem
+			if i < len(l) {
+				left = l[i]
+			}
+			if i < len(o) {
+				right = o[i]
+			}
+			var result int
+			if left == nil {
+				if right != nil {
+					result = -1 * right.compare(left)
+				}
+			} else {
+				result = left.compare(right)
+			}
+			if result != 0 {
+				return result
+			}
+		}
+		return 0
+	}
+	return 0
+}
+
+func (l listItem) String() string {
+	b := &strings.Builder{}
+	for i, item := range l {
+		if i > 0 {
+			if _, ok := item.(listItem); ok {
+				b.WriteString("-")
+			} else {
+				b.WriteString(".")
+			}
+		}
+		b.WriteString(item.String())
+	}
+	return b.String()
+}
+
+// normalize drops trailing items that do not change the version, so 1.0.0 becomes 1
+func (l listItem) normalize() listItem {
+	for i := len(l) - 1; i >= 0; i-- {
+		if l[i].isNull() {
+			l = append(l[:i], l[i+1:]...)
+		} else if _, ok := l[i].(listItem); !ok {
+			break
+		}
+	}
+	return l
+}
+
+// parseVersionItems splits a version up, the same way ComparableVersion.parseVersion does
+func parseVersionItems(version string) listItem {
+	version = strings.ToLower(version)
+
+	// Sub-lists are built up on a stack, and attached to their parent once they are finished
+	stack := []listItem{{}}
+	push := func() {
+		stack = append(stack, listItem{})
+	}
+	add := func(item versionItem) {
+		stack[len(stack)-1] = append(stack[len(stack)-1], item)
+	}
+	parseItem := func(isDigit bool, value string) versionItem {
+		if isDigit {
+			value = strings.TrimLeft(value, "0")
+			if value == "" {
+				value = "0"
+			}
+			return intItem(value)
+		}
+		return newStringItem(value, false)
+	}
+
+	isDigit := false
+	start := 0
+	for i := 0; i < len(version); i++ {
+		c := version[i]
+		switch {
+		case c == '.':
+			if i == start {
+				add(intItem("0"))
+			} else {
+				add(parseItem(isDigit, version[start:i]))
+			}
+			start = i + 1
+		case c == '-':
+			if i == start {
+				add(intItem("0"))
+			} else {
+				add(parseItem(isDigit, version[start:i]))
+			}
+			start = i + 1
+			push()
+		case c >= '0' && c <= '9':
+			if !isDigit && i > start {
+				add(newStringItem(version[start:i], true))
+				start = i
+				push()
+			}
+			isDigit = true
+		default:
+			if isDigit && i > start {
+				add(parseItem(true, version[start:i]))
+				start = i
+				push()
+			}
+			isDigit = false
+		}
+	}
+	if len(version) > start {
+		add(parseItem(isDigit, version[start:]))
+	}
+
+	// Normalize each sub-list from the inside out, attaching it to its parent as we go
+	for len(stack) > 1 {
+		list := stack[len(stack)-1].normalize()
+		stack = stack[:len(stack)-1]
+		stack[len(stack)-1] = append(stack[len(stack)-1], list)
+	}
+	return stack[0].normalize()
+}
+
+// Restriction is a single range of versions, such as [1.0,2.0).
+// A nil bound means the range is unbounded on that side.
+type Restriction struct {
+	Lower          *Version
+	LowerInclusive bool
+	Upper          *Version
+	UpperInclusive bool
+}
+
+// Contains checks whether version falls inside of the restriction
+func (r Restriction) Contains(version Version) bool {
+	if r.Lower != nil {
+		c := r.Lower.Compare(version)
+		if c > 0 || (c == 0 && !r.LowerInclusive) {
+			return false
+		}
+	}
+	if r.Upper != nil {
+		c := r.Upper.Compare(version)
+		if c < 0 || (c == 0 && !r.UpperInclusive) {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the restriction in Maven's range syntax
+func (r Restriction) String() string {
+	b := &strings.Builder{}
+	if r.LowerInclusive {
+		b.WriteString("[")
+	} else {
+		b.WriteString("(")
+	}
+	if r.Lower != nil {
+		b.WriteString(r.Lower.String())
+	}
+	if r.Lower == nil || r.Upper == nil || r.Lower.Compare(*r.Upper) != 0 || !r.LowerInclusive || !r.UpperInclusive {
+		b.WriteString(",")
+		if r.Upper != nil {
+			b.WriteString(r.Upper.String())
+		}
+	}
+	if r.UpperInclusive {
+		b.WriteString("]")
+	} else {
+		b.WriteString(")")
+	}
+	return b.String()
+}
+
+// everything is the restriction of a plain version like 1.0, which is only a recommendation
+var everything = Restriction{}
+
+// VersionRange is a dependency version as Maven reads it.  A plain version
+// such as 1.0 is a soft requirement: it is the Recommended version, but any
+// version is allowed.  Anything in brackets or parentheses is a hard requirement:
+//   [1.0]            exactly 1.0
+//   [1.0,2.0)        1.0 <= x < 2.0
+//   (,1.0],[1.2,)    x <= 1.0 or x >= 1.2
+type VersionRange struct {
+	Recommended  *Version
+	Restrictions []Restriction
+}
+
+// ParseVersionRange parses a version or version range
+func ParseVersionRange(spec string) (VersionRange, error) {
+	process := strings.TrimSpace(spec)
+	restrictions := make([]Restriction, 0)
+	var upperBound *Version
+	for strings.HasPrefix(process, "[") || strings.HasPrefix(process, "(") {
+		index1 := strings.Index(process, ")")
+		index2 := strings.Index(process, "]")
+		index := index2
+		if index2 < 0 || (index1 >= 0 && index1 < index2) {
+			index = index1
+		}
+		if index < 0 {
+			return VersionRange{}, fmt.Errorf("pom: unbounded range %q", spec)
+		}
+		restriction, err := parseRestriction(process[:index+1])
+		if err != nil {
+			return VersionRange{}, err
+		}
+		if upperBound != nil && (restriction.Lower == nil || restriction.Lower.Compare(*upperBound) < 0) {
+			return VersionRange{}, fmt.Errorf("pom: ranges overlap in %q", spec)
+		}
+		restrictions = append(restrictions, restriction)
+		upperBound = restriction.Upper
+
+		process = strings.TrimSpace(process[index+1:])
+		if strings.HasPrefix(process, ",") {
+			process = strings.TrimSpace(process[1:])
+		}
+	}
+
+	if process != "" {
+		if len(restrictions) > 0 {
+			return VersionRange{}, fmt.Errorf("pom: only fully qualified sets are allowed in multiple set ranges: %q", spec)
+		}
+		version := ParseVersion(process)
+		return VersionRange{Recommended: &version, Restrictions: []Restriction{everything}}, nil
+	}
+	if len(restrictions) == 0 {
+		return VersionRange{}, fmt.Errorf("pom: empty version range %q", spec)
+	}
+	return VersionRange{Restrictions: restrictions}, nil
+}
+
+// parseRestriction parses a single bracketed range
+func parseRestriction(spec string) (Restriction, error) {
+	r := Restriction{
+		LowerInclusive: strings.HasPrefix(spec, "["),
+		UpperInclusive: strings.HasSuffix(spec, "]"),
+	}
+	process := strings.TrimSpace(spec[1 : len(spec)-1])
+	index := strings.Index(process, ",")
+	if index < 0 {
+		if !r.LowerInclusive || !r.UpperInclusive {
+			return r, fmt.Errorf("pom: single version must be surrounded by []: %q", spec)
+		}
+		version := ParseVersion(process)
+		r.Lower, r.Upper = &version, &version
+		return r, nil
+	}
+
+	lower := strings.TrimSpace(process[:index])
+	upper := strings.TrimSpace(process[index+1:])
+	if lower == upper && lower != "" {
+		return r, fmt.Errorf("pom: range cannot have identical boundaries: %q", spec)
+	}
+	if lower != "" {
+		version := ParseVersion(lower)
+		r.Lower = &version
+	}
+	if upper != "" {
+		version := ParseVersion(upper)
+		r.Upper = &version
+	}
+	if r.Lower != nil && r.Upper != nil && r.Upper.Compare(*r.Lower) < 0 {
+		return r, fmt.Errorf("pom: range defies version ordering: %q", spec)
+	}
+	return r, nil
+}
+
+// HasRestrictions is true if the range is a hard requirement, rather than just a recommended version
+func (r VersionRange) HasRestrictions() bool {
+	return len(r.Restrictions) > 0 && r.Recommended == nil
+}
+
+// Contains checks whether version is allowed by the range
+func (r VersionRange) Contains(version Version) bool {
+	for _, restriction := range r.Restrictions {
+		if restriction.Contains(version) {
+			return true
+		}
+	}
+	return false
+}
+
+// Match returns the highest of versions that is allowed by the range,
+// which is the version Maven would pick
+func (r VersionRange) Match(versions []Version) (Version, bool) {
+	var best *Version
+	for i := range versions {
+		if r.Contains(versions[i]) && (best == nil || versions[i].Compare(*best) > 0) {
+			best = &versions[i]
+		}
+	}
+	if best == nil {
+		return Version{}, false
+	}
+	return *best, true
+}
+
+// Restrict returns the intersection of two ranges.  The recommended version
+// is kept if the intersection still allows it.
+func (r VersionRange) Restrict(other VersionRange) VersionRange {
+	restrictions := make([]Restriction, 0)
+	for _, a := range r.Restrictions {
+		for _, b := range other.Restrictions {
+			if intersection, ok := intersectRestrictions(a, b); ok {
+				restrictions = append(restrictions, intersection)
+			}
+		}
+	}
+
+	var version *Version
+	if len(restrictions) > 0 {
+		for _, restriction := range restrictions {
+			if r.Recommended != nil && restriction.Contains(*r.Recommended) {
+				version = r.Recommended
+				break
+			} else if version == nil && other.Recommended != nil && restriction.Contains(*other.Recommended) {
+				version = other.Recommended
+			}
+		}
+	} else if len(r.Restrictions) == 0 || len(other.Restrictions) == 0 {
+		// One of the ranges had no restrictions at all
+		if r.Recommended != nil {
+			version = r.Recommended
+		} else {
+			version = other.Recommended
+		}
+	}
+	return VersionRange{Recommended: version, Restrictions: restrictions}
+}
+
+// intersectRestrictions returns the overlap between two restrictions, if there is one
+func intersectRestrictions(a, b Restriction) (Restriction, bool) {
+	result := a
+	if b.Lower != nil {
+		if result.Lower == nil {
+			result.Lower, result.LowerInclusive = b.Lower, b.LowerInclusive
+		} else if c := b.Lower.Compare(*result.Lower); c > 0 {
+			result.Lower, result.LowerInclusive = b.Lower, b.LowerInclusive
+		} else if c == 0 {
+			result.LowerInclusive = result.LowerInclusive && b.LowerInclusive
+		}
+	}
+	if b.Upper != nil {
+		if result.Upper == nil {
+			result.Upper, result.UpperInclusive = b.Upper, b.UpperInclusive
+		} else if c := b.Upper.Compare(*result.Upper); c < 0 {
+			result.Upper, result.UpperInclusive = b.Upper, b.UpperInclusive
+		} else if c == 0 {
+			result.UpperInclusive = result.UpperInclusive && b.UpperInclusive
+		}
+	}
+	if result.Lower != nil && result.Upper != nil {
+		c := result.Lower.Compare(*result.Upper)
+		if c > 0 || (c == 0 && !(result.LowerInclusive && result.UpperInclusive)) {
+			return result, false
+		}
+	}
+	return result, true
+}
+
+// String returns the range as it would be written in a POM
+func (r VersionRange) String() string {
+	if r.Recommended != nil {
+		return r.Recommended.String()
+	}
+	parts := make([]string, 0, len(r.Restrictions))
+	for _, restriction := range r.Restrictions {
+		parts = append(parts, restriction.String())
+	}
+	return strings.Join(parts, ",")
+}
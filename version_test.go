@@ -0,0 +1,121 @@
+package pom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersionOrdering(t *testing.T) {
+	a := assert.New(t)
+
+	// Each list is in ascending order, taken from Maven's ComparableVersionTest
+	orderings := [][]string{
+		{"1-alpha2snapshot", "1-alpha2", "1-alpha-123", "1-beta-2", "1-beta123", "1-m2", "1-m11", "1-rc", "1-cr2",
+			"1-rc123", "1-SNAPSHOT", "1", "1-sp", "1-sp2", "1-sp123", "1-abc", "1-def", "1-pom-1", "1-1-snapshot",
+			"1-1", "1-2", "1-123"},
+		{"2.0", "2-1", "2.0.a", "2.0.0.a", "2.0.2", "2.0.123", "2.1.0", "2.1-a", "2.1b", "2.1-c", "2.1-1", "2.1.0.1",
+			"2.2", "2.123", "11.a2", "11.a11", "11.b2", "11.b11", "11.m2", "11.m11", "11", "11.a", "11b", "11c", "11m"},
+	}
+	for _, ordering := range orderings {
+		for i := range ordering {
+			for j := range ordering {
+				expected := 0
+				if i < j {
+					expected = -1
+				} else if i > j {
+					expected = 1
+				}
+				a.Equal(expected, ParseVersion(ordering[i]).Compare(ParseVersion(ordering[j])), "%s vs %s", ordering[i], ordering[j])
+			}
+		}
+	}
+
+	equal := [][]string{
+		{"1", "1.0", "1.0.0", "1-0", "1-ga", "1-final", "1-release", "1.0-GA"},
+		{"1a1", "1-a1", "1-alpha-1", "1alpha1"},
+		{"1b2", "1-beta-2", "1.0-BETA2"},
+		{"1m3", "1-milestone-3", "1.0.0-M3"},
+		{"1rc", "1cr", "1-rc", "1-CR"},
+		{"1.000000000000000000000000000000001", "1.1"},
+	}
+	for _, versions := range equal {
+		for _, v := range versions {
+			a.Equal(0, ParseVersion(versions[0]).Compare(ParseVersion(v)), "%s vs %s", versions[0], v)
+			a.Equal(ParseVersion(versions[0]).Canonical(), ParseVersion(v).Canonical())
+		}
+	}
+
+	a.Equal(1, ParseVersion("123456789012345678901234567890").Compare(ParseVersion("99999999999999999999999999999")))
+	a.Equal("1.0-GA", ParseVersion("1.0-GA").String())
+	a.Equal("1-alpha-1", ParseVersion("1a1").Canonical())
+}
+
+func TestVersionRange(t *testing.T) {
+	a := assert.New(t)
+	v := ParseVersion
+
+	r, err := ParseVersionRange("1.0")
+	a.NoError(err)
+	a.False(r.HasRestrictions())
+	a.Equal("1.0", r.Recommended.String())
+	a.True(r.Contains(v("0.1")))
+	a.True(r.Contains(v("9")))
+
+	r, err = ParseVersionRange("[1.0]")
+	a.NoError(err)
+	a.True(r.HasRestrictions())
+	a.True(r.Contains(v("1")))
+	a.False(r.Contains(v("1.0.1")))
+	a.Equal("[1.0]", r.String())
+
+	r, err = ParseVersionRange("[1.0,2.0)")
+	a.NoError(err)
+	a.True(r.Contains(v("1.0")))
+	a.True(r.Contains(v("1.9.9")))
+	a.False(r.Contains(v("2.0")))
+	a.True(r.Contains(v("2.0-SNAPSHOT")))
+	a.False(r.Contains(v("0.9")))
+
+	r, err = ParseVersionRange("(,1.0],[1.2,)")
+	a.NoError(err)
+	a.Len(r.Restrictions, 2)
+	a.True(r.Contains(v("0.5")))
+	a.False(r.Contains(v("1.1")))
+	a.True(r.Contains(v("1.2")))
+	a.Equal("(,1.0],[1.2,)", r.String())
+
+	match, ok := r.Match([]Version{v("0.9"), v("1.0"), v("1.1"), v("1.3")})
+	a.True(ok)
+	a.Equal("1.3", match.String())
+
+	for _, bad := range []string{"[1.0", "(1.0)", "[2.0,1.0]", "[1.0,1.0)", "[1.0,2.0],[1.5,3.0]", "[1.0,2.0],1.5", ""} {
+		_, err := ParseVersionRange(bad)
+		a.Error(err, bad)
+	}
+}
+
+func TestVersionRangeRestrict(t *testing.T) {
+	a := assert.New(t)
+
+	soft, _ := ParseVersionRange("1.5")
+	hard, _ := ParseVersionRange("[1.0,2.0)")
+	other, _ := ParseVersionRange("[1.7,3.0]")
+	outside, _ := ParseVersionRange("[2.0,3.0]")
+
+	r := soft.Restrict(hard)
+	a.Equal("1.5", r.Recommended.String())
+	a.Equal("[1.0,2.0)", r.Restrictions[0].String())
+
+	r = hard.Restrict(other)
+	a.Nil(r.Recommended)
+	a.Equal("[1.7,2.0)", r.String())
+
+	r = hard.Restrict(outside)
+	a.Empty(r.Restrictions)
+	a.False(r.Contains(ParseVersion("2.0")))
+
+	r = soft.Restrict(other)
+	a.Nil(r.Recommended)
+	a.Equal("[1.7,3.0]", r.String())
+}
@@ -0,0 +1,264 @@
+package pom
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strconv"
+	"strings"
+)
+
+type nodeKind int
+
+const (
+	documentNode nodeKind = iota
+	elementNode
+	textNode
+	commentNode
+	procInstNode
+	directiveNode
+)
+
+// node is a single piece of a parsed XML document, along with the byte range
+// it was read from.  Keeping the ranges around lets us write a document back
+// out exactly as we found it, only touching the parts that changed.
+type node struct {
+	kind     nodeKind
+	name     xml.Name
+	attrs    []xml.Attr
+	text     string
+	children []*node
+	parent   *node
+
+	// start and end cover the whole node, including the start and end tags.
+	// innerStart and innerEnd cover just the content of an element.
+	start, end           int
+	innerStart, innerEnd int
+	selfClosing          bool
+}
+
+// parseTree reads raw XML into a tree of nodes, keeping track of where in
+// raw each node came from.  raw has to be UTF-8, see toUTF8.
+func parseTree(raw []byte) (*node, error) {
+	d := xml.NewDecoder(bytes.NewReader(raw))
+	// raw is always UTF-8 by now, whatever it declares
+	d.CharsetReader = passthroughCharsetReader
+	root := &node{kind: documentNode, end: len(raw), innerEnd: len(raw)}
+	current := root
+	fail := func(offset int, msg string) error {
+		line, column := lineColumn(lineOffsets(raw), offset)
+		return &SyntaxError{Location: Location{Line: line, Column: column, Path: current.path()}, Msg: msg}
+	}
+	for {
+		pos := int(d.InputOffset())
+		// RawToken keeps namespace prefixes as they were written
+		tok, err := d.RawToken()
+		if err == io.EOF {
+			break
+		} else if syntaxErr, ok := err.(*xml.SyntaxError); ok {
+			return nil, fail(int(d.InputOffset()), syntaxErr.Msg)
+		} else if err != nil {
+			return nil, err
+		}
+		end := int(d.InputOffset())
+		switch t := tok.(type) {
+		case xml.StartElement:
+			n := &node{
+				kind:       elementNode,
+				name:       t.Name,
+				attrs:      t.Copy().Attr,
+				parent:     current,
+				start:      pos,
+				innerStart: end,
+			}
+			current.children = append(current.children, n)
+			current = n
+		case xml.EndElement:
+			if current.kind != elementNode || current.name != t.Name {
+				return nil, fail(pos, "unexpected end element </"+qualifiedName(t.Name)+">")
+			}
+			current.innerEnd = pos
+			current.end = end
+			// The decoder hands us an end element for <a/> without reading
+			// anything, so a zero width end tag means it was self closing.
+			if pos == end {
+				current.selfClosing = true
+				current.innerStart = end
+				current.innerEnd = end
+			}
+			current = current.parent
+		case xml.CharData:
+			current.children = append(current.children, &node{kind: textNode, text: string(t), parent: current, start: pos, end: end})
+		case xml.Comment:
+			current.children = append(current.children, &node{kind: commentNode, text: string(t), parent: current, start: pos, end: end})
+		case xml.ProcInst:
+			current.children = append(current.children, &node{kind: procInstNode, name: xml.Name{Local: t.Target}, text: string(t.Inst), parent: current, start: pos, end: end})
+		case xml.Directive:
+			current.children = append(current.children, &node{kind: directiveNode, text: string(t), parent: current, start: pos, end: end})
+		}
+	}
+	if current != root {
+		return nil, fail(len(raw), "unexpected EOF")
+	}
+	return root, nil
+}
+
+// path returns where the element n is in its document, in the form Positions.Lookup takes.
+// Only elements with siblings of the same name get an index.
+func (n *node) path() string {
+	if n == nil || n.kind != elementNode {
+		return ""
+	}
+	step := n.name.Local
+	if n.parent == nil {
+		return step
+	}
+	if siblings := namedElements(n.parent, n.name.Local); len(siblings) > 1 {
+		for i, sibling := range siblings {
+			if sibling == n {
+				step += "[" + strconv.Itoa(i) + "]"
+			}
+		}
+	}
+	if parent := n.parent.path(); parent != "" {
+		return parent + "/" + step
+	}
+	return step
+}
+
+// elements returns the element children of n
+func (n *node) elements() []*node {
+	result := make([]*node, 0, len(n.children))
+	for _, c := range n.children {
+		if c.kind == elementNode {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// hasElements is true if any child of n is an element
+func (n *node) hasElements() bool {
+	for _, c := range n.children {
+		if c.kind == elementNode {
+			return true
+		}
+	}
+	return false
+}
+
+// textContent joins all of the character data directly inside of n
+func (n *node) textContent() string {
+	var b strings.Builder
+	for _, c := range n.children {
+		if c.kind == textNode {
+			b.WriteString(c.text)
+		}
+	}
+	return b.String()
+}
+
+// sameNode compares two elements by name, attributes, text and children.
+// Comments and whitespace between elements are not considered.
+func sameNode(a, b *node) bool {
+	if a.name.Local != b.name.Local || len(a.attrs) != len(b.attrs) {
+		return false
+	}
+	for i := range a.attrs {
+		if a.attrs[i].Name.Local != b.attrs[i].Name.Local || a.attrs[i].Value != b.attrs[i].Value {
+			return false
+		}
+	}
+	aElems, bElems := a.elements(), b.elements()
+	if len(aElems) != len(bElems) {
+		return false
+	}
+	if len(aElems) == 0 {
+		return a.textContent() == b.textContent()
+	}
+	for i := range aElems {
+		if !sameNode(aElems[i], bElems[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// sameNodes reports whether two lists of elements are the same, in the same order
+func sameNodes(a, b []*node) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !sameNode(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// escapeText escapes character data.  Unlike xml.EscapeText, newlines and
+// tabs are left alone so multi-line values stay readable.
+func escapeText(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
+
+// escapeAttr escapes an attribute value for the given quote character
+func escapeAttr(s string, quote byte) string {
+	if quote == '\'' {
+		return strings.NewReplacer("&", "&amp;", "<", "&lt;", "'", "&apos;").Replace(s)
+	}
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", `"`, "&quot;").Replace(s)
+}
+
+// qualifiedName returns the name of an element or attribute as it was written,
+// which is the prefix and local name when read with RawToken
+func qualifiedName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	return name.Space + ":" + name.Local
+}
+
+// render writes a node and its children out as indented XML.
+// indent is the indentation of the node itself, and unit is added for each level below it.
+func (n *node) render(b *strings.Builder, indent, unit, newline string) {
+	switch n.kind {
+	case textNode:
+		b.WriteString(escapeText(n.text))
+		return
+	case commentNode:
+		b.WriteString("<!--" + n.text + "-->")
+		return
+	case procInstNode:
+		b.WriteString("<?" + n.name.Local + " " + n.text + "?>")
+		return
+	case directiveNode:
+		b.WriteString("<!" + n.text + ">")
+		return
+	}
+
+	b.WriteString("<" + qualifiedName(n.name))
+	for _, attr := range n.attrs {
+		b.WriteString(" " + qualifiedName(attr.Name) + `="` + escapeAttr(attr.Value, '"') + `"`)
+	}
+	if len(n.children) == 0 {
+		b.WriteString("/>")
+		return
+	}
+	b.WriteString(">")
+	if !n.hasElements() {
+		b.WriteString(escapeText(n.textContent()))
+	} else {
+		for _, c := range n.children {
+			// Whitespace between elements is replaced with our own indentation
+			if c.kind == textNode && strings.TrimSpace(c.text) == "" {
+				continue
+			}
+			b.WriteString(newline + indent + unit)
+			c.render(b, indent+unit, unit, newline)
+		}
+		b.WriteString(newline + indent)
+	}
+	b.WriteString("</" + qualifiedName(n.name) + ">")
+}